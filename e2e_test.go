@@ -10,8 +10,6 @@ import (
 	"testing"
 	"time"
 
-	"golang.org/x/mod/modfile"
-
 	"github.com/docker/docker/api/types/build"
 	"github.com/google/uuid"
 	"github.com/krelinga/video-workflows/vwrest"
@@ -21,50 +19,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
-func readModfile(t *testing.T) *modfile.File {
-	data, err := os.ReadFile("go.mod")
-	if err != nil {
-		t.Fatalf("failed to read go.mod: %v", err)
-	}
-
-	modFile, err := modfile.Parse("go.mod", data, nil)
-	if err != nil {
-		t.Fatalf("failed to parse go.mod: %v", err)
-	}
-
-	return modFile
-}
-
-func transcodeTag(t *testing.T) string {
-	modFile := readModfile(t)
-
-	for _, req := range modFile.Require {
-		if req.Mod.Path == "github.com/krelinga/video-transcoder" {
-			return req.Mod.Version
-		}
-	}
-
-	t.Fatal("github.com/krelinga/video-transcoder not found in go.mod")
-	return ""
-}
-
-func videoInfoTag(t *testing.T) string {
-	modFile := readModfile(t)
-
-	for _, req := range modFile.Require {
-		if req.Mod.Path == "github.com/krelinga/video-info" {
-			return req.Mod.Version
-		}
-	}
-
-	t.Fatal("github.com/krelinga/video-info not found in go.mod")
-	return ""
-}
-
 func TestEnd2End(t *testing.T) {
-	t.Logf("Using video-transcoder version: %s", transcodeTag(t))
-	t.Logf("Using video-info version: %s", videoInfoTag(t))
-
 	// Create temp directory for media files
 	tempDir, err := os.MkdirTemp("", "transcode-e2e-*")
 	if err != nil {
@@ -249,7 +204,9 @@ func inboxDir(tempDir string) string {
 	return filepath.Join(tempDir, "inbox")
 }
 
-// setup starts the various container that are necessary for this test.
+// setup starts the various container that are necessary for this test.  The video-info and transcoder
+// services are the in-memory fakes from internal/fakes, so the test needs neither their images nor their
+// databases.
 // It returns a host:port string for the workflow server.
 func setup(t *testing.T, ctx context.Context, tempDir string) string {
 	// Create library directory.
@@ -271,197 +228,48 @@ func setup(t *testing.T, ctx context.Context, tempDir string) string {
 	}
 	networkName := net.Name
 
-	// Set up transcoder service and it's deps.
-	transcoderDbName := "videotranscoder"
-	const dbUser = "postgres"
-	const dbPassword = "postgres"
-	transcoderPostgresReq := testcontainers.ContainerRequest{
-		Image:        "postgres:16",
-		ExposedPorts: []string{"5432/tcp"},
-		Env: map[string]string{
-			"POSTGRES_DB":       transcoderDbName,
-			"POSTGRES_USER":     dbUser,
-			"POSTGRES_PASSWORD": dbPassword,
-		},
-		Networks:       []string{networkName},
-		NetworkAliases: map[string][]string{networkName: {"transcoderPostgres"}},
-		WaitingFor:     wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
-	}
-	transcoderPostgresContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: transcoderPostgresReq,
-		Started:          true,
-	})
-	if err != nil {
-		t.Fatalf("failed to start postgres container: %v", err)
-	}
-	t.Cleanup(func() {
-		dumpContainerLogs(t, ctx, transcoderPostgresContainer, "transcoderPostgres")
-	})
-	transcoderServerReq := testcontainers.ContainerRequest{
-		Image: "krelinga/video-transcoder:" + transcodeTag(t) + "-server",
-		Env: map[string]string{
-			"VT_DB_HOST":     "transcoderPostgres",
-			"VT_DB_PORT":     "5432",
-			"VT_DB_USER":     dbUser,
-			"VT_DB_PASSWORD": dbPassword,
-			"VT_DB_NAME":     transcoderDbName,
-			"VT_SERVER_PORT": "8080",
-		},
-		Networks:       []string{networkName},
-		NetworkAliases: map[string][]string{networkName: {"transcoderserver"}},
-		WaitingFor:     wait.ForLog("Starting HTTP server on port 8080"),
-	}
-	transcoderServerContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: transcoderServerReq,
-		Started:          true,
-	})
-	if err != nil {
-		t.Fatalf("failed to start transcoder server container: %v", err)
-	}
-	t.Cleanup(func() {
-		dumpContainerLogs(t, ctx, transcoderServerContainer, "transcoderServer")
-	})
-	transcoderWorkerReq := testcontainers.ContainerRequest{
-		Image: "krelinga/video-transcoder:" + transcodeTag(t) + "-worker",
-		Env: map[string]string{
-			"VT_DB_HOST":     "transcoderPostgres",
-			"VT_DB_PORT":     "5432",
-			"VT_DB_USER":     dbUser,
-			"VT_DB_PASSWORD": dbPassword,
-			"VT_DB_NAME":     transcoderDbName,
-		},
-		Networks:       []string{networkName},
-		NetworkAliases: map[string][]string{networkName: {"transcoderworker"}},
-		Mounts: testcontainers.Mounts(
-			testcontainers.BindMount(tempDir, "/nas/media"),
-		),
-		WaitingFor: wait.ForLog("Worker started"),
-	}
-	transcoderWorkerContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: transcoderWorkerReq,
-		Started:          true,
-	})
-	if err != nil {
-		t.Fatalf("failed to start transcoder worker container: %v", err)
-	}
-	t.Cleanup(func() {
-		dumpContainerLogs(t, ctx, transcoderWorkerContainer, "transcoderWorker")
-	})
-
-	// Set up video info service and it's deps.
-	videoInfoDbName := "videoinfo"
-	videoInfoPostgresReq := testcontainers.ContainerRequest{
-		Image:        "postgres:16",
-		ExposedPorts: []string{"5432/tcp"},
-		Env: map[string]string{
-			"POSTGRES_DB":       videoInfoDbName,
-			"POSTGRES_USER":     dbUser,
-			"POSTGRES_PASSWORD": dbPassword,
+	// Start the fake video-info and transcoder services in place of the real ones.
+	fakesReq := testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			Context:    ".",
+			Dockerfile: "Dockerfile",
+			BuildArgs:  map[string]*string{},
+			BuildOptionsModifier: func(buildOptions *build.ImageBuildOptions) {
+				buildOptions.Target = "fakes"
+			},
 		},
-		Networks:       []string{networkName},
-		NetworkAliases: map[string][]string{networkName: {"videoinfopostgres"}},
-		WaitingFor:     wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
-	}
-	videoInfoPostgresContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: videoInfoPostgresReq,
-		Started:          true,
-	})
-	if err != nil {
-		t.Fatalf("failed to start video info postgres container: %v", err)
-	}
-	t.Cleanup(func() {
-		dumpContainerLogs(t, ctx, videoInfoPostgresContainer, "videoInfoPostgres")
-	})
-	videoInfoServerReq := testcontainers.ContainerRequest{
-		Image: "krelinga/video-info:" + videoInfoTag(t) + "-server",
 		Env: map[string]string{
-			"VI_DB_HOST":     "videoinfopostgres",
-			"VI_DB_PORT":     "5432",
-			"VI_DB_USER":     dbUser,
-			"VI_DB_PASSWORD": dbPassword,
-			"VI_DB_NAME":     videoInfoDbName,
-			"VI_SERVER_PORT": "8080",
+			"VW_FAKE_VIDEOINFO_ADDR": ":8081",
+			"VW_FAKE_TRANSCODE_ADDR": ":8082",
+			"VW_FAKE_DELAY":          "500ms",
+			"VW_FAKE_WRITE_OUTPUTS":  "true",
 		},
 		Networks:       []string{networkName},
-		NetworkAliases: map[string][]string{networkName: {"videoinfoserver"}},
-		WaitingFor:     wait.ForLog("Starting HTTP server on port 8080"),
-	}
-	videoInfoServerContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: videoInfoServerReq,
-		Started:          true,
-	})
-	if err != nil {
-		t.Fatalf("failed to start video info server container: %v", err)
-	}
-	t.Cleanup(func() {
-		dumpContainerLogs(t, ctx, videoInfoServerContainer, "videoInfoServer")
-	})
-	videoInfoWorkerReq := testcontainers.ContainerRequest{
-		Image: "krelinga/video-info:" + videoInfoTag(t) + "-worker",
-		Env: map[string]string{
-			"VI_DB_HOST":     "videoinfopostgres",
-			"VI_DB_PORT":     "5432",
-			"VI_DB_USER":     dbUser,
-			"VI_DB_PASSWORD": dbPassword,
-			"VI_DB_NAME":     videoInfoDbName,
-		},
+		NetworkAliases: map[string][]string{networkName: {"fakes"}},
 		Mounts: testcontainers.Mounts(
 			testcontainers.BindMount(tempDir, "/nas/media"),
 		),
-		Networks:       []string{networkName},
-		NetworkAliases: map[string][]string{networkName: {"videinfoworker"}},
-		WaitingFor:     wait.ForLog("Worker started"),
+		WaitingFor: wait.ForLog("Starting fake transcoder server"),
 	}
-	videoInfoWorkerContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: videoInfoWorkerReq,
+	fakesContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: fakesReq,
 		Started:          true,
 	})
 	if err != nil {
-		t.Fatalf("failed to start video info worker container: %v", err)
+		t.Fatalf("failed to start fakes container: %v", err)
 	}
 	t.Cleanup(func() {
-		dumpContainerLogs(t, ctx, videoInfoWorkerContainer, "videoInfoWorker")
+		dumpContainerLogs(t, ctx, fakesContainer, "fakes")
 	})
 
-	// Start workflows postgres
-	workflowsDbName := "videoworkflows"
-	workflowsPostgresReq := testcontainers.ContainerRequest{
-		Image:        "postgres:16",
-		ExposedPorts: []string{"5432/tcp"},
-		Env: map[string]string{
-			"POSTGRES_DB":       workflowsDbName,
-			"POSTGRES_USER":     dbUser,
-			"POSTGRES_PASSWORD": dbPassword,
-		},
-		Networks:       []string{networkName},
-		NetworkAliases: map[string][]string{networkName: {"workflowspostgres"}},
-		WaitingFor:     wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
-	}
-	workflowsPostgresContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: workflowsPostgresReq,
-		Started:          true,
-	})
-	if err != nil {
-		t.Fatalf("failed to start workflows postgres container: %v", err)
-	}
-	t.Cleanup(func() {
-		dumpContainerLogs(t, ctx, workflowsPostgresContainer, "workflowsPostgres")
-	})
-
-	// Start Temporal server with auto-setup (bootstraps postgres schema)
+	// Start a Temporal dev server, which keeps its state in memory.
 	temporalReq := testcontainers.ContainerRequest{
-		Image: "temporalio/auto-setup:latest",
-		Env: map[string]string{
-			"DB":             "postgres12",
-			"DB_PORT":        "5432",
-			"POSTGRES_USER":  dbUser,
-			"POSTGRES_PWD":   dbPassword,
-			"POSTGRES_SEEDS": "workflowspostgres",
-		},
+		Image:          "temporalio/temporal:latest",
+		Cmd:            []string{"server", "start-dev", "--ip", "0.0.0.0"},
 		ExposedPorts:   []string{"7233/tcp"},
 		Networks:       []string{networkName},
 		NetworkAliases: map[string][]string{networkName: {"temporal"}},
-		WaitingFor:     wait.ForLog("Temporal server started.").WithStartupTimeout(2 * time.Minute),
+		WaitingFor:     wait.ForListeningPort("7233/tcp").WithStartupTimeout(2 * time.Minute),
 	}
 	temporalContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: temporalReq,
@@ -486,19 +294,17 @@ func setup(t *testing.T, ctx context.Context, tempDir string) string {
 		},
 		ExposedPorts: []string{"8080/tcp"},
 		Env: map[string]string{
-			"VW_TEMPORAL_HOST":  "temporal",
-			"VW_TEMPORAL_PORT":  "7233",
-			"VW_TRANSCODE_HOST": "http://transcoderserver",
-			"VW_TRANSCODE_PORT": "8080",
-			"VW_VIDEOINFO_HOST": "http://videoinfoserver",
-			"VW_VIDEOINFO_PORT": "8080",
+			"VW_TEMPORAL_HOST": "temporal",
+			"VW_TEMPORAL_PORT": "7233",
+			"VW_TRANSCODE_URL": "http://fakes:8082",
+			"VW_VIDEOINFO_URL": "http://fakes:8081",
 		},
 		Networks:       []string{networkName},
 		NetworkAliases: map[string][]string{networkName: {"worker"}},
 		Mounts: testcontainers.Mounts(
 			testcontainers.BindMount(tempDir, "/nas/media"),
 		),
-		WaitingFor: wait.ForLog("Starting worker on task queue"),
+		WaitingFor: wait.ForLog("Starting worker"),
 	}
 	workerContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: workerReq,
@@ -535,7 +341,7 @@ func setup(t *testing.T, ctx context.Context, tempDir string) string {
 		Mounts: testcontainers.Mounts(
 			testcontainers.BindMount(tempDir, "/nas/media"),
 		),
-		WaitingFor: wait.ForLog("Starting server on :8080"),
+		WaitingFor: wait.ForLog("Starting server"),
 	}
 	serverContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: serverReq,
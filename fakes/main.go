@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/fakes"
+)
+
+func main() {
+	if err := mainImpl(); err != nil {
+		slog.Error("Exiting with error", internal.LogKeyError, err)
+		os.Exit(1)
+	}
+}
+
+func mainImpl() error {
+	config := internal.NewFakesConfigFromEnv()
+
+	behavior := func(path string) fakes.Behavior {
+		b := fakes.Behavior{
+			Delay:      config.Delay,
+			Heartbeats: config.Heartbeats,
+		}
+		if config.FailSubstring != "" && strings.Contains(path, config.FailSubstring) {
+			b.Error = fmt.Sprintf("fake failure for %s", path)
+		}
+		return b
+	}
+
+	videoInfo := fakes.NewVideoInfo(behavior, nil)
+	defer videoInfo.Close()
+	transcoder := fakes.NewTranscoder(behavior)
+	transcoder.WriteOutputs = config.WriteOutputs
+	defer transcoder.Close()
+
+	errCh := make(chan error, 2)
+	go func() {
+		slog.Info("Starting fake video-info server", "addr", config.VideoInfoAddr)
+		errCh <- http.ListenAndServe(config.VideoInfoAddr, videoInfo.Handler())
+	}()
+	go func() {
+		slog.Info("Starting fake transcoder server", "addr", config.TranscodeAddr)
+		errCh <- http.ListenAndServe(config.TranscodeAddr, transcoder.Handler())
+	}()
+
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
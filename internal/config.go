@@ -3,25 +3,73 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
-	EnvInboxPath      = "VW_INBOX_PATH"
-	EnvLibraryPath    = "VW_LIBRARY_PATH"
-	EnvPreviewPath    = "VW_PREVIEW_PATH"
-	EnvTemporalHost   = "VW_TEMPORAL_HOST"
-	EnvTemporalPort   = "VW_TEMPORAL_PORT"
-	EnvTranscodeHost  = "VW_TRANSCODE_HOST"
-	EnvTranscodePort  = "VW_TRANSCODE_PORT"
-	EnvVideoInfoHost  = "VW_VIDEOINFO_HOST"
-	EnvVideoInfoPort  = "VW_VIDEOINFO_PORT"
-	EnvWebhookBaseURI = "VW_WEBHOOK_BASE_URI"
+	EnvAllowBareCallbackTokens          = "VW_ALLOW_BARE_CALLBACK_TOKENS"
+	EnvCallbackMode                     = "VW_CALLBACK_MODE"
+	EnvFakeDelay                        = "VW_FAKE_DELAY"
+	EnvFakeFailSubstring                = "VW_FAKE_FAIL_SUBSTRING"
+	EnvFakeHeartbeats                   = "VW_FAKE_HEARTBEATS"
+	EnvFakeTranscodeAddr                = "VW_FAKE_TRANSCODE_ADDR"
+	EnvFakeVideoInfoAddr                = "VW_FAKE_VIDEOINFO_ADDR"
+	EnvFakeWriteOutputs                 = "VW_FAKE_WRITE_OUTPUTS"
+	EnvFilesystemActivitiesPerSecond    = "VW_FILESYSTEM_ACTIVITIES_PER_SECOND"
+	EnvFilesystemTaskQueue              = "VW_FILESYSTEM_TASK_QUEUE"
+	EnvInboxPath                        = "VW_INBOX_PATH"
+	EnvLibraryPath                      = "VW_LIBRARY_PATH"
+	EnvLogLevel                         = "VW_LOG_LEVEL"
+	EnvMaxConcurrentPreviews            = "VW_MAX_CONCURRENT_PREVIEWS"
+	EnvPollInterval                     = "VW_POLL_INTERVAL"
+	EnvPreviewPath                      = "VW_PREVIEW_PATH"
+	EnvRemoteActivitiesPerSecond        = "VW_REMOTE_ACTIVITIES_PER_SECOND"
+	EnvRemoteTaskQueue                  = "VW_REMOTE_TASK_QUEUE"
+	EnvStorePath                        = "VW_STORE_PATH"
+	EnvStoreTaskQueue                   = "VW_STORE_TASK_QUEUE"
+	EnvTemporalHost                     = "VW_TEMPORAL_HOST"
+	EnvTemporalPort                     = "VW_TEMPORAL_PORT"
+	EnvTracingExporter                  = "VW_TRACING_EXPORTER"
+	EnvTracingOTLPEndpoint              = "VW_TRACING_OTLP_ENDPOINT"
+	EnvTracingOTLPInsecure              = "VW_TRACING_OTLP_INSECURE"
+	EnvTranscodeAuthHeader              = "VW_TRANSCODE_AUTH_HEADER"
+	EnvTranscodeCAFile                  = "VW_TRANSCODE_CA_FILE"
+	EnvTranscodeHost                    = "VW_TRANSCODE_HOST"
+	EnvTranscodePort                    = "VW_TRANSCODE_PORT"
+	EnvTranscodeRetries                 = "VW_TRANSCODE_RETRIES"
+	EnvTranscodeRetryBackoff            = "VW_TRANSCODE_RETRY_BACKOFF"
+	EnvTranscodeTimeout                 = "VW_TRANSCODE_TIMEOUT"
+	EnvTranscodeURL                     = "VW_TRANSCODE_URL"
+	EnvVideoInfoAuthHeader              = "VW_VIDEOINFO_AUTH_HEADER"
+	EnvVideoInfoCAFile                  = "VW_VIDEOINFO_CA_FILE"
+	EnvVideoInfoHost                    = "VW_VIDEOINFO_HOST"
+	EnvVideoInfoPort                    = "VW_VIDEOINFO_PORT"
+	EnvVideoInfoRetries                 = "VW_VIDEOINFO_RETRIES"
+	EnvVideoInfoRetryBackoff            = "VW_VIDEOINFO_RETRY_BACKOFF"
+	EnvVideoInfoTimeout                 = "VW_VIDEOINFO_TIMEOUT"
+	EnvVideoInfoURL                     = "VW_VIDEOINFO_URL"
+	EnvWebhookBaseURI                   = "VW_WEBHOOK_BASE_URI"
+	EnvWorkerMaxConcurrentActivities    = "VW_WORKER_MAX_CONCURRENT_ACTIVITIES"
+	EnvWorkerMaxConcurrentWorkflowTasks = "VW_WORKER_MAX_CONCURRENT_WORKFLOW_TASKS"
+	EnvWorkerRoles                      = "VW_WORKER_ROLES"
 )
 
 var (
-	ErrPanicEnvNotSet = errors.New("environment variable not set")
-	ErrPanicEnvNotInt = errors.New("environment variable is not an integer")
+	ErrPanicEnvNotSet      = errors.New("environment variable not set")
+	ErrPanicEnvNotInt      = errors.New("environment variable is not an integer")
+	ErrPanicEnvNotBool     = errors.New("environment variable is not a boolean")
+	ErrPanicEnvNotFloat    = errors.New("environment variable is not a number")
+	ErrPanicEnvNegative    = errors.New("environment variable is negative")
+	ErrPanicEnvNotDuration = errors.New("environment variable is not a duration")
+	ErrPanicEnvNotLogLevel = errors.New("environment variable is not a log level")
+	ErrPanicEnvBadRole     = errors.New("environment variable contains an unknown worker role")
+	ErrPanicEnvBadCallback = errors.New("environment variable is not a callback mode")
 )
 
 type TemporalConfig struct {
@@ -29,20 +77,97 @@ type TemporalConfig struct {
 	Port int
 }
 
+// TracingConfig selects where OpenTelemetry spans are exported.
+type TracingConfig struct {
+	// Exporter is one of TracingExporterNone, TracingExporterStdout or TracingExporterOTLP.
+	Exporter string
+	// OTLPEndpoint is the host:port of the OTLP gRPC collector.  If empty, the
+	// standard OTEL_EXPORTER_OTLP_* environment variables are used.
+	OTLPEndpoint string
+	// OTLPInsecure disables TLS when talking to the OTLP collector.
+	OTLPInsecure bool
+	// Writer receives spans when Exporter is TracingExporterStdout.  Defaults to os.Stdout.
+	Writer io.Writer
+}
+
+// TaskQueueConfig names the task queues that activities are routed to.
+type TaskQueueConfig struct {
+	Filesystem string
+	Remote     string
+	Store      string
+}
+
+// ConcurrencyConfig limits how much work a worker takes on.  Zero values leave the Temporal SDK defaults
+// in place.
+type ConcurrencyConfig struct {
+	// MaxConcurrentActivities caps activities executing at once on each of this worker's task queues.
+	MaxConcurrentActivities int
+	// MaxConcurrentWorkflowTasks caps workflow tasks executing at once.
+	MaxConcurrentWorkflowTasks int
+	// FilesystemActivitiesPerSecond is the task-queue-wide dispatch rate for filesystem activities.
+	FilesystemActivitiesPerSecond float64
+	// RemoteActivitiesPerSecond is the task-queue-wide dispatch rate for activities that start
+	// video-info and transcode jobs.  Because those activities complete asynchronously, this is what
+	// throttles how quickly a disc with many titles can hand preview transcodes to the transcoder.
+	RemoteActivitiesPerSecond float64
+}
+
+// DependencyConfig describes how to reach an HTTP service that the worker calls.
+type DependencyConfig struct {
+	// BaseURL is the service's base URL, including the scheme and any path prefix.
+	BaseURL string
+	// Timeout bounds each request, including its retries.  Zero means no timeout.
+	Timeout time.Duration
+	// MaxRetries is how many times a request the service could not have acted on is retried.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry; it doubles for each one after.
+	RetryBackoff time.Duration
+	// CAFile is a PEM bundle of certificates to trust in addition to the system roots.  Empty means only
+	// the system roots.
+	CAFile string
+	// AuthHeader is sent as the Authorization header of every request.  Empty means none is sent.
+	AuthHeader string
+}
+
+type LoggingConfig struct {
+	Level slog.Level
+}
+
 type ServerConfig struct {
 	Temporal       *TemporalConfig
+	Tracing        *TracingConfig
+	Logging        *LoggingConfig
+	TaskQueues     *TaskQueueConfig
 	InboxPath      string
 	LibraryPath    string
 	PreviewPath    string
 	WebhookBaseURI string
+	// MaxConcurrentPreviews caps in-flight preview transcodes per disc.  Zero uses the workflow default.
+	MaxConcurrentPreviews int
+	// StorePath is the SQLite database that keeps disc outcomes after Temporal purges their histories.
+	// Empty disables it.
+	StorePath string
+	// AllowBareCallbackTokens accepts callbacks whose token is a bare Temporal task token, as sent for jobs
+	// started before tokens carried the job UUID.  Such callbacks cannot be checked against their job, so
+	// this is only for draining those jobs after an upgrade.  It defaults to true for this release, so that
+	// jobs in flight during the upgrade still complete, and will default to false in the next one.
+	AllowBareCallbackTokens bool
 }
 
 type WorkerConfig struct {
-	Temporal      *TemporalConfig
-	TranscodeHost string
-	TranscodePort int
-	VideoInfoHost string
-	VideoInfoPort int
+	Temporal    *TemporalConfig
+	Tracing     *TracingConfig
+	Logging     *LoggingConfig
+	TaskQueues  *TaskQueueConfig
+	Concurrency *ConcurrencyConfig
+	// Roles lists the WorkerRole* values this worker serves.
+	Roles     []string
+	Transcode *DependencyConfig
+	VideoInfo *DependencyConfig
+	// CallbackMode is one of the CallbackMode* values.
+	CallbackMode string
+	// PollInterval is how often jobs are polled in CallbackModePoll.
+	PollInterval time.Duration
 }
 
 func mustGetenv(key string) string {
@@ -53,6 +178,57 @@ func mustGetenv(key string) string {
 	return value
 }
 
+func getenvDefault(key string, fallback string) string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	return value
+}
+
+func getenvBool(key string, fallback bool) bool {
+	valueStr, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		panic(fmt.Errorf("%w: %s", ErrPanicEnvNotBool, key))
+	}
+	return value
+}
+
+func getenvInt(key string, fallback int) int {
+	if _, ok := os.LookupEnv(key); !ok {
+		return fallback
+	}
+	return mustGetenvInt(key)
+}
+
+func getenvFloat(key string, fallback float64) float64 {
+	valueStr, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		panic(fmt.Errorf("%w: %s", ErrPanicEnvNotFloat, key))
+	}
+	return value
+}
+
+func getenvDuration(key string, fallback time.Duration) time.Duration {
+	valueStr, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	value, err := time.ParseDuration(valueStr)
+	if err != nil {
+		panic(fmt.Errorf("%w: %s", ErrPanicEnvNotDuration, key))
+	}
+	return value
+}
+
 func mustGetenvInt(key string) int {
 	valueStr := mustGetenv(key)
 	var value int
@@ -70,22 +246,177 @@ func newTemporalConfigFromEnv() *TemporalConfig {
 	}
 }
 
+func newTracingConfigFromEnv() *TracingConfig {
+	return &TracingConfig{
+		Exporter:     getenvDefault(EnvTracingExporter, TracingExporterNone),
+		OTLPEndpoint: getenvDefault(EnvTracingOTLPEndpoint, ""),
+		OTLPInsecure: getenvBool(EnvTracingOTLPInsecure, false),
+	}
+}
+
+func newLoggingConfigFromEnv() *LoggingConfig {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(getenvDefault(EnvLogLevel, "info"))); err != nil {
+		panic(fmt.Errorf("%w: %s", ErrPanicEnvNotLogLevel, EnvLogLevel))
+	}
+	return &LoggingConfig{
+		Level: level,
+	}
+}
+
+func newTaskQueueConfigFromEnv() *TaskQueueConfig {
+	return &TaskQueueConfig{
+		Filesystem: getenvDefault(EnvFilesystemTaskQueue, DefaultFilesystemTaskQueue),
+		Remote:     getenvDefault(EnvRemoteTaskQueue, DefaultRemoteTaskQueue),
+		Store:      getenvDefault(EnvStoreTaskQueue, DefaultStoreTaskQueue),
+	}
+}
+
+func newConcurrencyConfigFromEnv() *ConcurrencyConfig {
+	return &ConcurrencyConfig{
+		MaxConcurrentActivities:       getenvInt(EnvWorkerMaxConcurrentActivities, 0),
+		MaxConcurrentWorkflowTasks:    getenvInt(EnvWorkerMaxConcurrentWorkflowTasks, 0),
+		FilesystemActivitiesPerSecond: getenvFloat(EnvFilesystemActivitiesPerSecond, 0),
+		RemoteActivitiesPerSecond:     getenvFloat(EnvRemoteActivitiesPerSecond, 0),
+	}
+}
+
+// dependencyEnv names the environment variables that configure one DependencyConfig.
+type dependencyEnv struct {
+	URL, Host, Port, Timeout, Retries, RetryBackoff, CAFile, AuthHeader string
+}
+
+var (
+	transcodeEnv = dependencyEnv{
+		URL:          EnvTranscodeURL,
+		Host:         EnvTranscodeHost,
+		Port:         EnvTranscodePort,
+		Timeout:      EnvTranscodeTimeout,
+		Retries:      EnvTranscodeRetries,
+		RetryBackoff: EnvTranscodeRetryBackoff,
+		CAFile:       EnvTranscodeCAFile,
+		AuthHeader:   EnvTranscodeAuthHeader,
+	}
+	videoInfoEnv = dependencyEnv{
+		URL:          EnvVideoInfoURL,
+		Host:         EnvVideoInfoHost,
+		Port:         EnvVideoInfoPort,
+		Timeout:      EnvVideoInfoTimeout,
+		Retries:      EnvVideoInfoRetries,
+		RetryBackoff: EnvVideoInfoRetryBackoff,
+		CAFile:       EnvVideoInfoCAFile,
+		AuthHeader:   EnvVideoInfoAuthHeader,
+	}
+)
+
+// newDependencyConfigFromEnv reads a DependencyConfig.  The base URL may be given in full, or, as before
+// full URLs were supported, as a host and port that are joined with a colon.
+func newDependencyConfigFromEnv(env dependencyEnv) *DependencyConfig {
+	baseURL := getenvDefault(env.URL, "")
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("%s:%d", mustGetenv(env.Host), mustGetenvInt(env.Port))
+	}
+	return &DependencyConfig{
+		BaseURL:      baseURL,
+		Timeout:      getenvDuration(env.Timeout, 30*time.Second),
+		MaxRetries:   getenvInt(env.Retries, 3),
+		RetryBackoff: getenvDuration(env.RetryBackoff, 500*time.Millisecond),
+		CAFile:       getenvDefault(env.CAFile, ""),
+		AuthHeader:   getenvDefault(env.AuthHeader, ""),
+	}
+}
+
+func workerRolesFromEnv() []string {
+	value := getenvDefault(EnvWorkerRoles, strings.Join([]string{WorkerRoleWorkflow, WorkerRoleFilesystem, WorkerRoleRemote}, ","))
+	var roles []string
+	for _, role := range strings.Split(value, ",") {
+		role = strings.TrimSpace(role)
+		switch role {
+		case WorkerRoleWorkflow, WorkerRoleFilesystem, WorkerRoleRemote:
+			roles = append(roles, role)
+		case "":
+		default:
+			panic(fmt.Errorf("%w: %s: %s", ErrPanicEnvBadRole, EnvWorkerRoles, role))
+		}
+	}
+	return roles
+}
+
+func callbackModeFromEnv() string {
+	mode := getenvDefault(EnvCallbackMode, CallbackModeToken)
+	switch mode {
+	case CallbackModeToken, CallbackModeID, CallbackModePoll:
+		return mode
+	default:
+		panic(fmt.Errorf("%w: %s: %s", ErrPanicEnvBadCallback, EnvCallbackMode, mode))
+	}
+}
+
+// HasRole reports whether the worker is configured to serve role.
+func (c *WorkerConfig) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
 func NewServerConfigFromEnv() *ServerConfig {
 	return &ServerConfig{
-		Temporal:       newTemporalConfigFromEnv(),
-		InboxPath:      mustGetenv(EnvInboxPath),
-		LibraryPath:    mustGetenv(EnvLibraryPath),
-		PreviewPath:    mustGetenv(EnvPreviewPath),
-		WebhookBaseURI: mustGetenv(EnvWebhookBaseURI),
+		Temporal:                newTemporalConfigFromEnv(),
+		Tracing:                 newTracingConfigFromEnv(),
+		Logging:                 newLoggingConfigFromEnv(),
+		TaskQueues:              newTaskQueueConfigFromEnv(),
+		InboxPath:               mustGetenv(EnvInboxPath),
+		LibraryPath:             mustGetenv(EnvLibraryPath),
+		PreviewPath:             mustGetenv(EnvPreviewPath),
+		WebhookBaseURI:          mustGetenv(EnvWebhookBaseURI),
+		MaxConcurrentPreviews:   getenvInt(EnvMaxConcurrentPreviews, 0),
+		StorePath:               getenvDefault(EnvStorePath, ""),
+		AllowBareCallbackTokens: getenvBool(EnvAllowBareCallbackTokens, true),
 	}
 }
 
 func NewWorkerConfigFromEnv() *WorkerConfig {
-	return &WorkerConfig{
-		Temporal:      newTemporalConfigFromEnv(),
-		TranscodeHost: mustGetenv(EnvTranscodeHost),
-		TranscodePort: mustGetenvInt(EnvTranscodePort),
-		VideoInfoHost: mustGetenv(EnvVideoInfoHost),
-		VideoInfoPort: mustGetenvInt(EnvVideoInfoPort),
+	config := &WorkerConfig{
+		Temporal:    newTemporalConfigFromEnv(),
+		Tracing:     newTracingConfigFromEnv(),
+		Logging:     newLoggingConfigFromEnv(),
+		TaskQueues:  newTaskQueueConfigFromEnv(),
+		Concurrency: newConcurrencyConfigFromEnv(),
+		Roles:       workerRolesFromEnv(),
+	}
+	// The video-info and transcoder services are only needed by workers that run remote activities.
+	if config.HasRole(WorkerRoleRemote) {
+		config.Transcode = newDependencyConfigFromEnv(transcodeEnv)
+		config.VideoInfo = newDependencyConfigFromEnv(videoInfoEnv)
+		config.CallbackMode = callbackModeFromEnv()
+		config.PollInterval = getenvDuration(EnvPollInterval, 10*time.Second)
+	}
+	return config
+}
+
+// FakesConfig configures the fake video-info and transcoder services used for local development.
+type FakesConfig struct {
+	VideoInfoAddr string
+	TranscodeAddr string
+	// Delay is how long each fake job takes before calling back.
+	Delay time.Duration
+	// Heartbeats is the number of progress callbacks each fake transcode sends.
+	Heartbeats int
+	// FailSubstring makes any job whose input path contains it fail.  Empty means no job fails.
+	FailSubstring string
+	// WriteOutputs makes fake transcodes create their output files.
+	WriteOutputs bool
+}
+
+func NewFakesConfigFromEnv() *FakesConfig {
+	config := &FakesConfig{
+		VideoInfoAddr: getenvDefault(EnvFakeVideoInfoAddr, ":8081"),
+		TranscodeAddr: getenvDefault(EnvFakeTranscodeAddr, ":8082"),
+		Delay:         getenvDuration(EnvFakeDelay, 2*time.Second),
+		Heartbeats:    getenvInt(EnvFakeHeartbeats, 3),
+		FailSubstring: getenvDefault(EnvFakeFailSubstring, ""),
+		WriteOutputs:  getenvBool(EnvFakeWriteOutputs, true),
+	}
+	if config.Heartbeats < 0 {
+		panic(fmt.Errorf("%w: %s", ErrPanicEnvNegative, EnvFakeHeartbeats))
 	}
+	return config
 }
@@ -0,0 +1,128 @@
+// Package fakes provides in-memory implementations of the video-info and video-transcoder REST APIs.
+//
+// The fakes accept jobs, optionally send progress heartbeats, and then POST to the webhook URIs supplied
+// with each job using the same payloads as the real services.  They are used by tests through
+// httptest.Server and by the fakes binary for local development without the real services.
+package fakes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/krelinga/video-workflows/internal"
+)
+
+// Behavior controls how a single fake job plays out.
+type Behavior struct {
+	// Delay is how long the job runs before its completion webhook is sent.
+	Delay time.Duration
+	// Heartbeats is the number of progress webhooks sent, evenly spaced across Delay.  Only the
+	// transcoder sends heartbeats.  Negative values are treated as zero.
+	Heartbeats int
+	// Error, if non-empty, makes the job fail with this message.
+	Error string
+	// CreateStatus, if non-zero, is returned from the create request (e.g. 400, 409 or 500) instead of
+	// starting the job.
+	CreateStatus int
+	// SkipCallback suppresses the completion webhook, as if the service crashed mid-job.
+	SkipCallback bool
+}
+
+// DefaultBehavior is used when no BehaviorFunc is configured.
+var DefaultBehavior = Behavior{
+	Delay:      100 * time.Millisecond,
+	Heartbeats: 1,
+}
+
+// BehaviorFunc chooses the Behavior for the job working on path.
+type BehaviorFunc func(path string) Behavior
+
+// runner runs fake jobs in the background and sends their webhooks.
+type runner struct {
+	client   *http.Client
+	behavior BehaviorFunc
+
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newRunner(behavior BehaviorFunc) *runner {
+	ctx, cancel := context.WithCancel(context.Background())
+	if behavior == nil {
+		behavior = func(string) Behavior { return DefaultBehavior }
+	}
+	return &runner{
+		client:   &http.Client{Timeout: 30 * time.Second},
+		behavior: behavior,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// run calls job in the background.  job should return early when ctx is done.
+func (r *runner) run(job func(ctx context.Context)) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		job(r.ctx)
+	}()
+}
+
+// sleep waits for d, returning false if the runner was closed first.
+func (r *runner) sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// post sends body as JSON to uri, logging rather than returning failures since there is nobody to
+// report them to.
+func (r *runner) post(ctx context.Context, uri string, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to marshal webhook payload", internal.LogKeyError, err)
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(data))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create webhook request", "uri", uri, internal.LogKeyError, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to send webhook request", "uri", uri, internal.LogKeyError, err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		slog.ErrorContext(ctx, "Webhook request failed", "uri", uri, "status", resp.StatusCode)
+	}
+}
+
+// Close stops all running jobs without sending further webhooks and waits for them to exit.
+func (r *runner) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// Wait blocks until all jobs started so far have sent their webhooks.
+func (r *runner) Wait() {
+	r.wg.Wait()
+}
+
+func errorMessage(code int) string {
+	return fmt.Sprintf("fake %s", http.StatusText(code))
+}
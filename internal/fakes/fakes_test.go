@@ -0,0 +1,264 @@
+package fakes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-info/virest"
+	"github.com/krelinga/video-transcoder/vtrest"
+	"github.com/krelinga/video-workflows/vwrest"
+)
+
+// webhookRecorder collects the JSON bodies POSTed to each path.
+type webhookRecorder struct {
+	mu     sync.Mutex
+	bodies map[string][]json.RawMessage
+}
+
+func newWebhookServer(t *testing.T) (*webhookRecorder, *httptest.Server) {
+	recorder := &webhookRecorder{bodies: make(map[string][]json.RawMessage)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode webhook body: %v", err)
+		}
+		recorder.mu.Lock()
+		recorder.bodies[r.URL.Path] = append(recorder.bodies[r.URL.Path], body)
+		recorder.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return recorder, server
+}
+
+func (r *webhookRecorder) get(t *testing.T, path string, index int, v any) {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.bodies[path]) <= index {
+		t.Fatalf("expected at least %d webhooks to %s, got %d", index+1, path, len(r.bodies[path]))
+	}
+	if err := json.Unmarshal(r.bodies[path][index], v); err != nil {
+		t.Fatalf("failed to unmarshal webhook body: %v", err)
+	}
+}
+
+func (r *webhookRecorder) count(path string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies[path])
+}
+
+func TestVideoInfo(t *testing.T) {
+	recorder, webhooks := newWebhookServer(t)
+	fake := NewVideoInfo(func(path string) Behavior {
+		if strings.Contains(path, "bad") {
+			return Behavior{Delay: time.Millisecond, Error: "corrupt file"}
+		}
+		return Behavior{Delay: time.Millisecond}
+	}, nil)
+	defer fake.Close()
+	server := httptest.NewServer(fake.Handler())
+	defer server.Close()
+
+	client, err := virest.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	token := []byte("token-data")
+	webhookURI := webhooks.URL + "/info"
+
+	goodUUID := uuid.New()
+	resp, err := client.CreateInfoWithResponse(ctx, virest.CreateInfoJSONRequestBody{
+		Uuid:         goodUUID,
+		VideoPath:    "/nas/good.mkv",
+		WebhookUri:   &webhookURI,
+		WebhookToken: token,
+	})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	if resp.JSON201 == nil {
+		t.Fatalf("expected 201, got %d", resp.StatusCode())
+	}
+
+	// Reusing a UUID is a conflict.
+	resp, err = client.CreateInfoWithResponse(ctx, virest.CreateInfoJSONRequestBody{
+		Uuid:      goodUUID,
+		VideoPath: "/nas/good.mkv",
+	})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	if resp.JSON409 == nil {
+		t.Fatalf("expected 409, got %d", resp.StatusCode())
+	}
+
+	badUUID := uuid.New()
+	resp, err = client.CreateInfoWithResponse(ctx, virest.CreateInfoJSONRequestBody{
+		Uuid:         badUUID,
+		VideoPath:    "/nas/bad.mkv",
+		WebhookUri:   &webhookURI,
+		WebhookToken: token,
+	})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	if resp.JSON201 == nil {
+		t.Fatalf("expected 201, got %d", resp.StatusCode())
+	}
+
+	fake.Wait()
+	if got := recorder.count("/info"); got != 2 {
+		t.Fatalf("expected 2 webhooks, got %d", got)
+	}
+	callbacks := map[uuid.UUID]vwrest.CompleteGetVideoInfoActivityRequest{}
+	for i := range 2 {
+		var callback vwrest.CompleteGetVideoInfoActivityRequest
+		recorder.get(t, "/info", i, &callback)
+		if string(callback.Token) != string(token) {
+			t.Errorf("expected token %q, got %q", token, callback.Token)
+		}
+		callbacks[*callback.Uuid] = callback
+	}
+	good := callbacks[goodUUID]
+	if good.Result == nil || good.Result.TotalDurationSeconds == nil || *good.Result.TotalDurationSeconds != DefaultVideoInfo.TotalDurationSeconds {
+		t.Errorf("expected default result, got %+v", good.Result)
+	}
+	bad := callbacks[badUUID]
+	if bad.Error == nil || *bad.Error != "corrupt file" {
+		t.Errorf("expected error callback, got %+v", bad)
+	}
+
+	status, err := client.GetInfoStatusWithResponse(ctx, badUUID)
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if status.JSON200 == nil || status.JSON200.Status != virest.Failed {
+		t.Errorf("expected failed status, got %s", string(status.Body))
+	}
+}
+
+func TestTranscoder(t *testing.T) {
+	recorder, webhooks := newWebhookServer(t)
+	fake := NewTranscoder(func(path string) Behavior {
+		if strings.Contains(path, "busy") {
+			return Behavior{CreateStatus: http.StatusInternalServerError}
+		}
+		return Behavior{Delay: 3 * time.Millisecond, Heartbeats: 2}
+	})
+	defer fake.Close()
+	fake.WriteOutputs = true
+	server := httptest.NewServer(fake.Handler())
+	defer server.Close()
+
+	client, err := vtrest.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+	completeURI := webhooks.URL + "/complete"
+	heartbeatURI := webhooks.URL + "/heartbeat"
+	destination := t.TempDir() + "/out.mp4"
+
+	jobUUID := uuid.New()
+	resp, err := client.CreateTranscodeWithResponse(ctx, vtrest.CreateTranscodeJSONRequestBody{
+		Uuid:                jobUUID,
+		SourcePath:          "/nas/in.mkv",
+		DestinationPath:     destination,
+		Profile:             "preview",
+		WebhookUri:          &completeURI,
+		HeartbeatWebhookUri: &heartbeatURI,
+		WebhookToken:        []byte("token-data"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	if resp.JSON201 == nil {
+		t.Fatalf("expected 201, got %d", resp.StatusCode())
+	}
+
+	resp, err = client.CreateTranscodeWithResponse(ctx, vtrest.CreateTranscodeJSONRequestBody{
+		Uuid:            uuid.New(),
+		SourcePath:      "/nas/busy.mkv",
+		DestinationPath: destination,
+		Profile:         "preview",
+	})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	if resp.JSON500 == nil {
+		t.Fatalf("expected 500, got %d", resp.StatusCode())
+	}
+
+	fake.Wait()
+	if got := recorder.count("/heartbeat"); got != 2 {
+		t.Fatalf("expected 2 heartbeats, got %d", got)
+	}
+	var heartbeat vwrest.HeartbeatTranscodeActivityRequest
+	recorder.get(t, "/heartbeat", 1, &heartbeat)
+	if heartbeat.Progress <= 0 || heartbeat.Progress >= 100 {
+		t.Errorf("expected progress between 0 and 100, got %f", heartbeat.Progress)
+	}
+	var complete vwrest.CompleteTranscodeActivityRequest
+	recorder.get(t, "/complete", 0, &complete)
+	if complete.Error != nil {
+		t.Errorf("expected success, got error %s", *complete.Error)
+	}
+	if *complete.Uuid != jobUUID {
+		t.Errorf("expected UUID %s, got %s", jobUUID, complete.Uuid)
+	}
+	if _, err := os.Stat(destination); err != nil {
+		t.Errorf("expected output file to be written: %v", err)
+	}
+	if jobs := fake.Jobs(); len(jobs) != 1 || jobs[0].Status != vtrest.Completed {
+		t.Errorf("expected one completed job, got %+v", jobs)
+	}
+}
+
+func TestTranscoder_NegativeHeartbeats(t *testing.T) {
+	recorder, webhooks := newWebhookServer(t)
+	fake := NewTranscoder(func(string) Behavior {
+		return Behavior{Delay: time.Millisecond, Heartbeats: -1}
+	})
+	defer fake.Close()
+	server := httptest.NewServer(fake.Handler())
+	defer server.Close()
+
+	client, err := vtrest.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	completeURI := webhooks.URL + "/complete"
+	heartbeatURI := webhooks.URL + "/heartbeat"
+	resp, err := client.CreateTranscodeWithResponse(context.Background(), vtrest.CreateTranscodeJSONRequestBody{
+		Uuid:                uuid.New(),
+		SourcePath:          "/nas/in.mkv",
+		DestinationPath:     "/nas/out.mp4",
+		Profile:             "preview",
+		WebhookUri:          &completeURI,
+		HeartbeatWebhookUri: &heartbeatURI,
+	})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	if resp.JSON201 == nil {
+		t.Fatalf("expected 201, got %d", resp.StatusCode())
+	}
+
+	fake.Wait()
+	if got := recorder.count("/heartbeat"); got != 0 {
+		t.Errorf("expected no heartbeats, got %d", got)
+	}
+	if got := recorder.count("/complete"); got != 1 {
+		t.Errorf("expected one completion, got %d", got)
+	}
+}
@@ -0,0 +1,167 @@
+package fakes
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/krelinga/video-transcoder/vtrest"
+	"github.com/krelinga/video-workflows/vwrest"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Transcoder is a fake implementation of the video-transcoder service.
+type Transcoder struct {
+	*runner
+
+	// WriteOutputs makes successful jobs create an empty file at their destination path, for callers
+	// that check the preview exists on disk.
+	WriteOutputs bool
+
+	mu   sync.Mutex
+	jobs map[openapi_types.UUID]*vtrest.TranscodeJob
+}
+
+var _ vtrest.StrictServerInterface = (*Transcoder)(nil)
+
+// NewTranscoder creates a fake video-transcoder service.  behavior may be nil to use DefaultBehavior.
+func NewTranscoder(behavior BehaviorFunc) *Transcoder {
+	return &Transcoder{
+		runner: newRunner(behavior),
+		jobs:   make(map[openapi_types.UUID]*vtrest.TranscodeJob),
+	}
+}
+
+// Handler returns an http.Handler serving the video-transcoder REST API.
+func (f *Transcoder) Handler() http.Handler {
+	return vtrest.Handler(vtrest.NewStrictHandler(f, nil))
+}
+
+// Jobs returns a snapshot of every job accepted so far.
+func (f *Transcoder) Jobs() []vtrest.TranscodeJob {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jobs := make([]vtrest.TranscodeJob, 0, len(f.jobs))
+	for _, job := range f.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+func (f *Transcoder) updateJob(id openapi_types.UUID, update func(job *vtrest.TranscodeJob)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job := f.jobs[id]
+	update(job)
+	job.UpdatedAt = time.Now()
+}
+
+// CreateTranscode accepts a new job and completes it in the background according to its Behavior.
+func (f *Transcoder) CreateTranscode(ctx context.Context, request vtrest.CreateTranscodeRequestObject) (vtrest.CreateTranscodeResponseObject, error) {
+	if request.Body.SourcePath == "" || request.Body.DestinationPath == "" {
+		return vtrest.CreateTranscode400JSONResponse{Code: "BAD_REQUEST", Message: "sourcePath and destinationPath are required"}, nil
+	}
+	behavior := f.behavior(request.Body.SourcePath)
+	switch behavior.CreateStatus {
+	case 0, http.StatusCreated:
+	case http.StatusBadRequest:
+		return vtrest.CreateTranscode400JSONResponse{Code: "BAD_REQUEST", Message: errorMessage(behavior.CreateStatus)}, nil
+	case http.StatusConflict:
+		return vtrest.CreateTranscode409JSONResponse{Code: "CONFLICT", Message: errorMessage(behavior.CreateStatus)}, nil
+	default:
+		return vtrest.CreateTranscode500JSONResponse{Code: "INTERNAL_ERROR", Message: errorMessage(behavior.CreateStatus)}, nil
+	}
+
+	now := time.Now()
+	job := &vtrest.TranscodeJob{
+		Uuid:            request.Body.Uuid,
+		SourcePath:      request.Body.SourcePath,
+		DestinationPath: request.Body.DestinationPath,
+		Profile:         request.Body.Profile,
+		Status:          vtrest.Running,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	f.mu.Lock()
+	if _, exists := f.jobs[job.Uuid]; exists {
+		f.mu.Unlock()
+		return vtrest.CreateTranscode409JSONResponse{Code: "CONFLICT", Message: "job with this UUID already exists"}, nil
+	}
+	f.jobs[job.Uuid] = job
+	response := vtrest.CreateTranscode201JSONResponse(*job)
+	f.mu.Unlock()
+
+	body := *request.Body
+	f.run(func(ctx context.Context) {
+		// Split the delay into one interval per heartbeat plus a final one before completion.
+		heartbeats := max(behavior.Heartbeats, 0)
+		interval := behavior.Delay / time.Duration(heartbeats+1)
+		for i := 1; i <= heartbeats; i++ {
+			if !f.sleep(ctx, interval) {
+				return
+			}
+			progress := 100 * float64(i) / float64(heartbeats+1)
+			f.updateJob(body.Uuid, func(job *vtrest.TranscodeJob) {
+				job.Progress = progress
+			})
+			if behavior.SkipCallback || body.HeartbeatWebhookUri == nil {
+				continue
+			}
+			f.post(ctx, *body.HeartbeatWebhookUri, vwrest.HeartbeatTranscodeActivityRequest{
+				Token:    body.WebhookToken,
+				Uuid:     &body.Uuid,
+				Progress: progress,
+			})
+		}
+		if !f.sleep(ctx, interval) {
+			return
+		}
+
+		callback := vwrest.CompleteTranscodeActivityRequest{
+			Token: body.WebhookToken,
+			Uuid:  &body.Uuid,
+		}
+		if behavior.Error != "" {
+			f.updateJob(body.Uuid, func(job *vtrest.TranscodeJob) {
+				job.Status = vtrest.Failed
+				job.Error = &behavior.Error
+			})
+			callback.Error = &behavior.Error
+		} else {
+			if f.WriteOutputs {
+				if err := os.WriteFile(body.DestinationPath, nil, 0o644); err != nil {
+					message := err.Error()
+					callback.Error = &message
+				}
+			}
+			f.updateJob(body.Uuid, func(job *vtrest.TranscodeJob) {
+				if callback.Error != nil {
+					job.Status = vtrest.Failed
+					job.Error = callback.Error
+					return
+				}
+				job.Status = vtrest.Completed
+				job.Progress = 100
+			})
+		}
+		if behavior.SkipCallback || body.WebhookUri == nil {
+			return
+		}
+		f.post(ctx, *body.WebhookUri, callback)
+	})
+
+	return response, nil
+}
+
+// GetTranscodeStatus reports the current state of a job.
+func (f *Transcoder) GetTranscodeStatus(ctx context.Context, request vtrest.GetTranscodeStatusRequestObject) (vtrest.GetTranscodeStatusResponseObject, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job, ok := f.jobs[request.Uuid]
+	if !ok {
+		return vtrest.GetTranscodeStatus404JSONResponse{Code: "NOT_FOUND", Message: "job not found"}, nil
+	}
+	return vtrest.GetTranscodeStatus200JSONResponse(*job), nil
+}
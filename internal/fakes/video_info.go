@@ -0,0 +1,147 @@
+package fakes
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/krelinga/video-info/virest"
+	"github.com/krelinga/video-workflows/vwrest"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// DefaultVideoInfo is reported for every file when no ResultFunc is configured.
+var DefaultVideoInfo = virest.VideoInfo{
+	TotalDurationSeconds:    3600,
+	ChapterDurationsSeconds: []float64{1800, 1800},
+}
+
+// VideoInfoResultFunc chooses the info reported for the file at videoPath.
+type VideoInfoResultFunc func(videoPath string) virest.VideoInfo
+
+// VideoInfo is a fake implementation of the video-info service.
+type VideoInfo struct {
+	*runner
+	result VideoInfoResultFunc
+
+	mu   sync.Mutex
+	jobs map[openapi_types.UUID]*virest.InfoJob
+}
+
+var _ virest.StrictServerInterface = (*VideoInfo)(nil)
+
+// NewVideoInfo creates a fake video-info service.  Either argument may be nil to use the defaults.
+func NewVideoInfo(behavior BehaviorFunc, result VideoInfoResultFunc) *VideoInfo {
+	if result == nil {
+		result = func(string) virest.VideoInfo { return DefaultVideoInfo }
+	}
+	return &VideoInfo{
+		runner: newRunner(behavior),
+		result: result,
+		jobs:   make(map[openapi_types.UUID]*virest.InfoJob),
+	}
+}
+
+// Handler returns an http.Handler serving the video-info REST API.
+func (f *VideoInfo) Handler() http.Handler {
+	return virest.Handler(virest.NewStrictHandler(f, nil))
+}
+
+// Jobs returns a snapshot of every job accepted so far.
+func (f *VideoInfo) Jobs() []virest.InfoJob {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	jobs := make([]virest.InfoJob, 0, len(f.jobs))
+	for _, job := range f.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+func (f *VideoInfo) updateJob(id openapi_types.UUID, update func(job *virest.InfoJob)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job := f.jobs[id]
+	update(job)
+	job.UpdatedAt = time.Now()
+}
+
+// CreateInfo accepts a new job and completes it in the background according to its Behavior.
+func (f *VideoInfo) CreateInfo(ctx context.Context, request virest.CreateInfoRequestObject) (virest.CreateInfoResponseObject, error) {
+	if request.Body.VideoPath == "" {
+		return virest.CreateInfo400JSONResponse{Code: "BAD_REQUEST", Message: "videoPath is required"}, nil
+	}
+	behavior := f.behavior(request.Body.VideoPath)
+	switch behavior.CreateStatus {
+	case 0, http.StatusCreated:
+	case http.StatusBadRequest:
+		return virest.CreateInfo400JSONResponse{Code: "BAD_REQUEST", Message: errorMessage(behavior.CreateStatus)}, nil
+	case http.StatusConflict:
+		return virest.CreateInfo409JSONResponse{Code: "CONFLICT", Message: errorMessage(behavior.CreateStatus)}, nil
+	default:
+		return virest.CreateInfo500JSONResponse{Code: "INTERNAL_ERROR", Message: errorMessage(behavior.CreateStatus)}, nil
+	}
+
+	now := time.Now()
+	job := &virest.InfoJob{
+		Uuid:      request.Body.Uuid,
+		VideoPath: request.Body.VideoPath,
+		Status:    virest.Running,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	f.mu.Lock()
+	if _, exists := f.jobs[job.Uuid]; exists {
+		f.mu.Unlock()
+		return virest.CreateInfo409JSONResponse{Code: "CONFLICT", Message: "job with this UUID already exists"}, nil
+	}
+	f.jobs[job.Uuid] = job
+	response := virest.CreateInfo201JSONResponse(*job)
+	f.mu.Unlock()
+
+	body := *request.Body
+	f.run(func(ctx context.Context) {
+		if !f.sleep(ctx, behavior.Delay) {
+			return
+		}
+		callback := vwrest.CompleteGetVideoInfoActivityRequest{
+			Token: body.WebhookToken,
+			Uuid:  &body.Uuid,
+		}
+		if behavior.Error != "" {
+			f.updateJob(body.Uuid, func(job *virest.InfoJob) {
+				job.Status = virest.Failed
+				job.Error = &behavior.Error
+			})
+			callback.Error = &behavior.Error
+		} else {
+			result := f.result(body.VideoPath)
+			f.updateJob(body.Uuid, func(job *virest.InfoJob) {
+				job.Status = virest.Completed
+				job.Result = &result
+			})
+			callback.Result = &vwrest.CompleteGetVideoInfoActivityRequestResult{
+				TotalDurationSeconds:    &result.TotalDurationSeconds,
+				ChapterDurationsSeconds: result.ChapterDurationsSeconds,
+			}
+		}
+		if behavior.SkipCallback || body.WebhookUri == nil {
+			return
+		}
+		f.post(ctx, *body.WebhookUri, callback)
+	})
+
+	return response, nil
+}
+
+// GetInfoStatus reports the current state of a job.
+func (f *VideoInfo) GetInfoStatus(ctx context.Context, request virest.GetInfoStatusRequestObject) (virest.GetInfoStatusResponseObject, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job, ok := f.jobs[request.Uuid]
+	if !ok {
+		return virest.GetInfoStatus404JSONResponse{Code: "NOT_FOUND", Message: "job not found"}, nil
+	}
+	return virest.GetInfoStatus200JSONResponse(*job), nil
+}
@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+var ErrNoCACerts = errors.New("CA bundle contains no certificates")
+
+// NewDependencyHTTPClient returns a tracing http.Client for calling the service described by config.
+// Requests carry config's auth header, trust its CA bundle as well as the system roots, are retried per
+// its retry settings, and are bounded by its timeout.
+func NewDependencyHTTPClient(config *DependencyConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.CAFile != "" {
+		pool, err := loadCAPool(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	var rt http.RoundTripper = &retryTransport{
+		next:       transport,
+		maxRetries: config.MaxRetries,
+		backoff:    config.RetryBackoff,
+	}
+	if config.AuthHeader != "" {
+		rt = &authTransport{next: rt, value: config.AuthHeader}
+	}
+	return &http.Client{
+		Transport: otelhttp.NewTransport(rt),
+		Timeout:   config.Timeout,
+	}, nil
+}
+
+func loadCAPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: %s", ErrNoCACerts, path)
+	}
+	return pool, nil
+}
+
+// authTransport sets the Authorization header on every request.
+type authTransport struct {
+	next  http.RoundTripper
+	value string
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", t.value)
+	return t.next.RoundTrip(req)
+}
+
+// retryTransport retries requests that the service cannot have acted on: those whose connection could
+// not be made, and those answered by a 502, 503 or 504.  Other failures are left to the caller, because
+// repeating a request that was processed would turn an accepted job into a conflict.  The wait between
+// attempts starts at backoff and doubles each time.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	wait := t.backoff
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, errors.New("cannot retry request without GetBody")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		wait *= 2
+	}
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDependencyHTTPClient_RetriesUnavailable(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("expected the auth header, got %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewDependencyHTTPClient(&DependencyConfig{MaxRetries: 3, AuthHeader: "Bearer secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"uuid":"x"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected 201 after retries, got %d", resp.StatusCode)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(bodies))
+	}
+	for _, body := range bodies {
+		if body != `{"uuid":"x"}` {
+			t.Errorf("expected every attempt to carry the body, got %q", body)
+		}
+	}
+}
+
+func TestDependencyHTTPClient_DoesNotRetryServerError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewDependencyHTTPClient(&DependencyConfig{MaxRetries: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if attempts != 1 {
+		t.Errorf("expected a 500 not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestDependencyHTTPClient_BadCAFile(t *testing.T) {
+	path := t.TempDir() + "/ca.pem"
+	if err := os.WriteFile(path, []byte("not a certificate"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewDependencyHTTPClient(&DependencyConfig{CAFile: path}); err == nil {
+		t.Error("expected an error for a CA bundle without certificates")
+	}
+}
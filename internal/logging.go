@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	tlog "go.temporal.io/sdk/log"
+)
+
+// Keys used for correlation attributes.  The Temporal SDK adds WorkflowID, RunID and ActivityType
+// to workflow and activity loggers on its own.
+const (
+	LogKeyDiscUUID   = "disc_uuid"
+	LogKeyWorkflowID = "WorkflowID"
+	LogKeyFilePath   = "file_path"
+	LogKeyJobUUID    = "job_uuid"
+	LogKeyError      = "error"
+)
+
+const redactedValue = "[REDACTED]"
+
+// redactedKeyParts are the lower-case parts of attribute keys whose values are credentials.
+var redactedKeyParts = []string{"token", "auth", "secret", "password"}
+
+type logAttrsKey struct{}
+
+// WithLogAttrs returns a context carrying args (as accepted by slog.Logger.Info) that are attached to
+// every record logged with that context through a logger created by NewLogger.
+func WithLogAttrs(ctx context.Context, args ...any) context.Context {
+	existing, _ := ctx.Value(logAttrsKey{}).([]slog.Attr)
+	record := slog.Record{}
+	record.Add(args...)
+	attrs := make([]slog.Attr, 0, len(existing)+record.NumAttrs())
+	attrs = append(attrs, existing...)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	return context.WithValue(ctx, logAttrsKey{}, attrs)
+}
+
+// contextHandler adds the attributes stored by WithLogAttrs to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs, ok := ctx.Value(logAttrsKey{}).([]slog.Attr); ok {
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// redactAttr hides the value of any attribute that looks like a credential, so that Temporal task tokens,
+// auth headers and the like never reach the logs.
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	for _, part := range redactedKeyParts {
+		if strings.Contains(key, part) {
+			return slog.String(attr.Key, redactedValue)
+		}
+	}
+	return attr
+}
+
+// NewLogger creates a JSON logger at the configured level that writes to w (os.Stderr if nil).
+func NewLogger(config *LoggingConfig, w io.Writer) *slog.Logger {
+	if w == nil {
+		w = os.Stderr
+	}
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       config.Level,
+		ReplaceAttr: redactAttr,
+	})
+	return slog.New(contextHandler{handler})
+}
+
+// NewTemporalLogger adapts logger for use as a Temporal client and worker logger.
+func NewTemporalLogger(logger *slog.Logger) tlog.Logger {
+	return tlog.NewStructuredLogger(logger)
+}
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestNewLogger_Redacts(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    any
+		redacted bool
+	}{
+		{"task token", "task_token", []byte("secret-task-token"), true},
+		{"webhook token", "WebhookToken", "secret-webhook-token", true},
+		{"auth header", "auth_header", "Bearer secret", true},
+		{"authorization", "Authorization", "Bearer secret", true},
+		{"password", "db_password", "hunter2", true},
+		{"file path", LogKeyFilePath, "/nas/media/inbox/disc1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			NewLogger(&LoggingConfig{}, &out).Info("message", tt.key, tt.value)
+
+			var record map[string]any
+			if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+				t.Fatalf("failed to decode log record %q: %v", out.String(), err)
+			}
+			got, ok := record[tt.key]
+			if !ok {
+				t.Fatalf("expected %s in %s", tt.key, out.String())
+			}
+			if tt.redacted && got != redactedValue {
+				t.Errorf("expected %s to be redacted, got %v", tt.key, got)
+			}
+			if !tt.redacted && got == redactedValue {
+				t.Errorf("expected %s not to be redacted", tt.key)
+			}
+		})
+	}
+}
+
+func TestWithLogAttrs(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger(&LoggingConfig{}, &out)
+	ctx := WithLogAttrs(context.Background(), LogKeyDiscUUID, "disc-uuid")
+	ctx = WithLogAttrs(ctx, LogKeyJobUUID, "job-uuid", "token", "secret-token")
+
+	logger.InfoContext(ctx, "message")
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("failed to decode log record %q: %v", out.String(), err)
+	}
+	want := map[string]any{
+		LogKeyDiscUUID: "disc-uuid",
+		LogKeyJobUUID:  "job-uuid",
+		"token":        redactedValue,
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, record[key])
+		}
+	}
+
+	// Records logged without the context do not get its attributes.
+	out.Reset()
+	logger.Info("message")
+	if bytes.Contains(out.Bytes(), []byte(LogKeyDiscUUID)) {
+		t.Errorf("expected no context attributes, got %s", out.String())
+	}
+}
@@ -1,4 +1,39 @@
 package internal
 
-// TaskQueue is the Temporal task queue name for video workflows.
-const TaskQueue = "video-workflows"
\ No newline at end of file
+const (
+	// TaskQueue is the Temporal task queue name for video workflows.
+	TaskQueue = "video-workflows"
+
+	// DefaultFilesystemTaskQueue is the task queue for activities that touch the media filesystem, so only
+	// workers with the NAS mounted pick them up.
+	DefaultFilesystemTaskQueue = "video-workflows-filesystem"
+
+	// DefaultRemoteTaskQueue is the task queue for activities that start jobs on the video-info and
+	// video-transcoder services.
+	DefaultRemoteTaskQueue = "video-workflows-remote"
+
+	// DefaultStoreTaskQueue is the task queue for activities that write to the disc store, which only
+	// the server polls since it owns the store.
+	DefaultStoreTaskQueue = "video-workflows-store"
+)
+
+// Worker roles select which task queues a worker polls.
+const (
+	WorkerRoleWorkflow   = "workflow"
+	WorkerRoleFilesystem = "filesystem"
+	WorkerRoleRemote     = "remote"
+)
+
+// Callback modes select how the activity that started a job on the video-info or transcoder service
+// learns that the job has finished.
+const (
+	// CallbackModeToken sends the Temporal task token of the attempt that started the job.  A callback
+	// can only complete that attempt.
+	CallbackModeToken = "token"
+	// CallbackModeID sends the workflow and activity IDs instead, so a callback completes whichever
+	// attempt of the activity is current.
+	CallbackModeID = "id"
+	// CallbackModePoll asks for no callbacks at all.  The activity polls the service for the job's status
+	// instead, for networks where the services cannot reach the server.
+	CallbackModePoll = "poll"
+)
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	temporalotel "go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/interceptor"
+)
+
+// TracerName names the tracer for spans that this module starts itself, rather than through the Temporal
+// interceptor or HTTP instrumentation.
+const TracerName = "github.com/krelinga/video-workflows"
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+var ErrUnknownTracingExporter = errors.New("unknown tracing exporter")
+
+// NewTracerProvider creates a tracer provider for serviceName that exports spans as described by config.
+// The provider and W3C trace context propagators are installed as the OpenTelemetry globals, so the
+// Temporal interceptor and HTTP instrumentation pick them up.  Callers must Shutdown the provider on exit
+// to flush buffered spans.
+func NewTracerProvider(ctx context.Context, config *TracingConfig, serviceName string) (*sdktrace.TracerProvider, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+	}
+	switch config.Exporter {
+	case TracingExporterNone, "":
+		// Spans are still created so that trace context is propagated, but nothing is exported.
+	case TracingExporterStdout:
+		writer := config.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(writer))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout span exporter: %w", err)
+		}
+		// Export synchronously so that spans are visible as soon as they end.
+		options = append(options, sdktrace.WithSyncer(exporter))
+	case TracingExporterOTLP:
+		var otlpOptions []otlptracegrpc.Option
+		if config.OTLPEndpoint != "" {
+			otlpOptions = append(otlpOptions, otlptracegrpc.WithEndpoint(config.OTLPEndpoint))
+		}
+		if config.OTLPInsecure {
+			otlpOptions = append(otlpOptions, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, otlpOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP span exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTracingExporter, config.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return provider, nil
+}
+
+// NewTracingInterceptor returns a Temporal interceptor that records spans for workflow starts, workflows
+// and activities, and carries trace context between them in Temporal headers.
+func NewTracingInterceptor() (interceptor.Interceptor, error) {
+	return temporalotel.NewTracingInterceptor(temporalotel.TracerOptions{})
+}
+
+// NewTracingHandler wraps handler so that each incoming request gets a span, continuing any trace
+// context supplied by the caller.
+func NewTracingHandler(handler http.Handler, operation string) http.Handler {
+	return otelhttp.NewHandler(handler, operation)
+}
+
+// InjectTraceContext returns the W3C trace context of ctx as a map of header values, for carrying a trace
+// through a service that does not propagate it.  It returns nil if ctx has no trace.
+func InjectTraceContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// ExtractTraceContext returns ctx continuing the trace in carrier, as made by InjectTraceContext.  ctx is
+// returned unchanged if carrier is empty.
+func ExtractTraceContext(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
@@ -0,0 +1,97 @@
+package vwactivity
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	testJobUUID     = "550e8400-e29b-41d4-a716-446655440001"
+	testVideoPath   = "/nas/media/library/disc1/title_t00.mkv"
+	testPreviewPath = "/nas/media/previews/disc1/title_t00.mp4"
+	testCompleteURI = "http://server:8080/activity/transcode/complete"
+	testProgressURI = "http://server:8080/activity/transcode/heartbeat"
+	testInfoURI     = "http://server:8080/activity/get_video_info/complete"
+)
+
+// testPollInterval keeps polling tests fast.
+const testPollInterval = time.Millisecond
+
+var errTransport = errors.New("connection refused")
+
+func httpResponse(status int) *http.Response {
+	return &http.Response{StatusCode: status, Status: http.StatusText(status)}
+}
+
+type ActivityTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestActivityEnvironment
+	// callbackMode is passed to the activity deps under test.
+	callbackMode string
+}
+
+func TestActivityTestSuite(t *testing.T) {
+	suite.Run(t, new(ActivityTestSuite))
+}
+
+func (s *ActivityTestSuite) SetupTest() {
+	s.env = s.NewTestActivityEnvironment()
+	s.callbackMode = ""
+}
+
+// executeRetried runs an activity of deps from a workflow that gives it two attempts, so that tests can
+// see how a retry behaves.  It returns the workflow's error.
+func (s *ActivityTestSuite) executeRetried(deps any, activityFn any, params any) error {
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterActivity(deps)
+	env.ExecuteWorkflow(func(ctx workflow.Context) error {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			RetryPolicy:         &temporal.RetryPolicy{InitialInterval: time.Second, MaximumAttempts: 2},
+		})
+		return workflow.ExecuteActivity(ctx, activityFn, params).Get(ctx, nil)
+	})
+	s.Require().True(env.IsWorkflowCompleted())
+	return env.GetWorkflowError()
+}
+
+// assertRetryable checks that err is non-retryable with errorType, or retryable if errorType is empty.
+func (s *ActivityTestSuite) assertRetryable(err error, errorType string) {
+	var appErr *temporal.ApplicationError
+	s.Require().ErrorAs(err, &appErr)
+	if errorType == "" {
+		s.False(appErr.NonRetryable(), "expected a retryable error, got %v", err)
+		return
+	}
+	s.True(appErr.NonRetryable(), "expected a non-retryable error, got %v", err)
+	s.Equal(errorType, appErr.Type())
+}
+
+// assertCallbackToken checks that a webhook token ties the job UUID to the activity, in the way
+// callbackMode asks for.
+func (s *ActivityTestSuite) assertCallbackToken(data []byte) {
+	token, ok := ParseCallbackToken(data)
+	s.Require().True(ok, "expected a callback token, got %q", data)
+	s.Equal(testJobUUID, token.JobUUID)
+	s.NotEmpty(token.DiscUUID)
+	if s.callbackMode == internal.CallbackModeID {
+		s.True(token.ByID())
+		s.Empty(token.TaskToken)
+		s.NotEmpty(token.Namespace)
+		s.NotEmpty(token.WorkflowID)
+		s.NotEmpty(token.ActivityID)
+		return
+	}
+	s.False(token.ByID())
+	s.NotEmpty(token.TaskToken)
+}
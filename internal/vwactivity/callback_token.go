@@ -0,0 +1,82 @@
+package vwactivity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/krelinga/video-workflows/internal"
+	"go.temporal.io/sdk/activity"
+)
+
+// CallbackToken is sent to the video-info and transcoder services as the webhook token and comes back
+// with every callback for the job.  It ties the job's UUID to the activity that started it, so that the
+// server can refuse a callback whose UUID names a different job than the one the token was issued for.
+//
+// Depending on the callback mode, the activity is identified either by TaskToken or by Namespace,
+// WorkflowID, RunID and ActivityID.  DiscUUID names the disc the activity works for in every mode, so that
+// the server can tag its logs with it, and TraceContext carries the activity's trace to the callbacks,
+// since the services do not propagate it.
+type CallbackToken struct {
+	JobUUID    string `json:"job_uuid"`
+	DiscUUID   string `json:"disc_uuid,omitempty"`
+	TaskToken  []byte `json:"task_token,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	WorkflowID string `json:"workflow_id,omitempty"`
+	RunID      string `json:"run_id,omitempty"`
+	ActivityID string `json:"activity_id,omitempty"`
+
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+}
+
+// ByID reports whether the token identifies its activity by ID rather than by task token.
+func (t CallbackToken) ByID() bool {
+	return t.ActivityID != ""
+}
+
+// newCallbackToken encodes a CallbackToken for the activity running in ctx.  mode is one of the
+// internal.CallbackMode* values; empty means internal.CallbackModeToken.
+func newCallbackToken(ctx context.Context, mode string, jobUUID string) ([]byte, error) {
+	info := activity.GetInfo(ctx)
+	token := CallbackToken{
+		JobUUID:      jobUUID,
+		DiscUUID:     info.WorkflowExecution.ID,
+		TraceContext: internal.InjectTraceContext(ctx),
+	}
+	switch mode {
+	case internal.CallbackModeID:
+		token.Namespace = info.WorkflowNamespace
+		token.WorkflowID = info.WorkflowExecution.ID
+		token.RunID = info.WorkflowExecution.RunID
+		token.ActivityID = info.ActivityID
+	case internal.CallbackModeToken, "":
+		token.TaskToken = info.TaskToken
+	default:
+		return nil, fmt.Errorf("unknown callback mode %q", mode)
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode callback token: %w", err)
+	}
+	return data, nil
+}
+
+// ParseCallbackToken decodes a webhook token.  It returns false if data is not a CallbackToken, which is
+// the case for jobs started before tokens carried the job UUID; those sent the bare Temporal task token.
+func ParseCallbackToken(data []byte) (CallbackToken, bool) {
+	var token CallbackToken
+	if err := json.Unmarshal(data, &token); err != nil || token.JobUUID == "" {
+		return CallbackToken{}, false
+	}
+	if len(token.TaskToken) == 0 && (token.Namespace == "" || token.WorkflowID == "" || token.ActivityID == "") {
+		return CallbackToken{}, false
+	}
+	return token, true
+}
+
+// jobFromEarlierAttempt reports whether a conflict when creating a job means the job was already created
+// by an earlier attempt of this activity, which reuses the job's UUID.  Such a job is resumed rather than
+// failing the activity, so that a retry after a worker crash or heartbeat timeout picks the job up again.
+func jobFromEarlierAttempt(ctx context.Context) bool {
+	return activity.GetInfo(ctx).Attempt > 1
+}
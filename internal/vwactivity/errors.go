@@ -0,0 +1,49 @@
+package vwactivity
+
+import (
+	"net/http"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+// Application error types reported by activities that call other services.  Errors of these types will
+// fail the same way on every attempt, so they are returned as non-retryable.  Server errors, transport
+// failures, timeouts and rate limiting are left retryable.
+const (
+	ErrorTypeBadRequest = "BadRequest"
+	ErrorTypeConflict   = "Conflict"
+	// ErrorTypeUnauthorized is reported when a service refuses the worker's credentials, which needs the
+	// worker's configuration fixed rather than different input.
+	ErrorTypeUnauthorized = "Unauthorized"
+	// ErrorTypeJobFailed is reported when a job that a service accepted finishes with an error.  A retry
+	// would reuse the job's UUID, which the service refuses or answers with the same failed job, so the job's
+	// own error is kept instead.
+	ErrorTypeJobFailed = "JobFailed"
+)
+
+// NonRetryableErrorTypes lists the error types above, for use in activity RetryPolicy values.
+var NonRetryableErrorTypes = []string{ErrorTypeBadRequest, ErrorTypeConflict, ErrorTypeUnauthorized, ErrorTypeJobFailed}
+
+// JobFailedError reports that a job finished with errorMessage.
+func JobFailedError(errorMessage string) error {
+	return temporal.NewNonRetryableApplicationError(errorMessage, ErrorTypeJobFailed, nil)
+}
+
+// dependencyError classifies err, returned by a dependency with the given HTTP status, as retryable or
+// not.
+func dependencyError(status int, err error) error {
+	var errorType string
+	switch {
+	case status == http.StatusRequestTimeout, status == http.StatusTooManyRequests:
+		return err
+	case status == http.StatusConflict:
+		errorType = ErrorTypeConflict
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		errorType = ErrorTypeUnauthorized
+	case status >= 400 && status < 500:
+		errorType = ErrorTypeBadRequest
+	default:
+		return err
+	}
+	return temporal.NewNonRetryableApplicationError(err.Error(), errorType, err)
+}
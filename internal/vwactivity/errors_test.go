@@ -0,0 +1,41 @@
+package vwactivity
+
+import (
+	"errors"
+	"net/http"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+func (s *ActivityTestSuite) Test_DependencyError() {
+	tests := []struct {
+		status    int
+		errorType string
+	}{
+		{http.StatusBadRequest, ErrorTypeBadRequest},
+		{http.StatusUnprocessableEntity, ErrorTypeBadRequest},
+		{http.StatusConflict, ErrorTypeConflict},
+		{http.StatusUnauthorized, ErrorTypeUnauthorized},
+		{http.StatusForbidden, ErrorTypeUnauthorized},
+		{http.StatusRequestTimeout, ""},
+		{http.StatusTooManyRequests, ""},
+		{http.StatusInternalServerError, ""},
+		{http.StatusBadGateway, ""},
+	}
+	for _, tt := range tests {
+		s.Run(http.StatusText(tt.status), func() {
+			cause := errors.New("service message")
+
+			err := dependencyError(tt.status, cause)
+
+			s.ErrorIs(err, cause)
+			var appErr *temporal.ApplicationError
+			if tt.errorType == "" {
+				// Plain errors are retried by Temporal.
+				s.False(errors.As(err, &appErr), "expected a retryable error, got %v", err)
+				return
+			}
+			s.assertRetryable(err, tt.errorType)
+		})
+	}
+}
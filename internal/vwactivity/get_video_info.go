@@ -4,15 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/krelinga/video-info/virest"
+	"github.com/krelinga/video-workflows/internal"
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/log"
 )
 
 type GetVideoInfoParams struct {
-	Uuid      string `json:"uuid"`
-	VideoPath string `json:"video_path"`
+	Uuid               string `json:"uuid"`
+	VideoPath          string `json:"video_path"`
 	WebhookCompleteURI string `json:"webhook_complete_uri"`
 }
 
@@ -23,35 +27,122 @@ type VideoInfo struct {
 
 type VideoInfoDeps struct {
 	Client virest.ClientWithResponsesInterface
+	// CallbackMode is one of the internal.CallbackMode* values; empty means internal.CallbackModeToken.
+	CallbackMode string
+	// PollInterval is how often the job is polled in internal.CallbackModePoll.
+	PollInterval time.Duration
 }
 
 var ErrGetVideoInfo = errors.New("failed to get video info")
 
-func getVideoInfoError(errorMessage *virest.Error) error {
-	return fmt.Errorf("%w: %s", ErrGetVideoInfo, errorMessage.Message)
+func getVideoInfoError(status int, errorMessage *virest.Error) error {
+	return dependencyError(status, fmt.Errorf("%w: %s", ErrGetVideoInfo, errorMessage.Message))
 }
 
-func (d *VideoInfoDeps) GetVideoInfo(ctx context.Context, params GetVideoInfoParams) error {
+func (d *VideoInfoDeps) GetVideoInfo(ctx context.Context, params GetVideoInfoParams) (*VideoInfo, error) {
+	jobUUID := uuid.MustParse(params.Uuid)
 	req := virest.CreateInfoJSONRequestBody{
-		Uuid:      uuid.MustParse(params.Uuid),
+		Uuid:      jobUUID,
 		VideoPath: params.VideoPath,
-		WebhookUri: &params.WebhookCompleteURI,
-		WebhookToken: activity.GetInfo(ctx).TaskToken,
 	}
+	if d.CallbackMode != internal.CallbackModePoll {
+		token, err := newCallbackToken(ctx, d.CallbackMode, params.Uuid)
+		if err != nil {
+			return nil, err
+		}
+		req.WebhookUri = &params.WebhookCompleteURI
+		req.WebhookToken = token
+	}
+	logger := getLogger(ctx, internal.LogKeyFilePath, params.VideoPath, internal.LogKeyJobUUID, params.Uuid)
+	logger.Info("Requesting video info")
 	resp, err := d.Client.CreateInfoWithResponse(ctx, req)
 	switch {
 	case err != nil:
-		return fmt.Errorf("%w: unexpected error: %s", ErrGetVideoInfo, err)
+		return nil, fmt.Errorf("%w: unexpected error: %s", ErrGetVideoInfo, err)
 	case resp.JSON201 != nil:
-		// Will be completed asynchronously
-		return activity.ErrResultPending
+		return d.waitForJob(ctx, logger, jobUUID)
 	case resp.JSON400 != nil:
-		return getVideoInfoError(resp.JSON400)
+		return nil, getVideoInfoError(http.StatusBadRequest, resp.JSON400)
+	case resp.JSON409 != nil && jobFromEarlierAttempt(ctx):
+		logger.Info("Job already started by an earlier attempt, resuming it")
+		return d.resumeJob(ctx, logger, jobUUID)
 	case resp.JSON409 != nil:
-		return getVideoInfoError(resp.JSON409)
+		return nil, getVideoInfoError(http.StatusConflict, resp.JSON409)
 	case resp.JSON500 != nil:
-		return getVideoInfoError(resp.JSON500)
+		return nil, getVideoInfoError(http.StatusInternalServerError, resp.JSON500)
+	default:
+		return nil, dependencyError(resp.StatusCode(), fmt.Errorf("%w: unexpected response status %d", ErrGetVideoInfo, resp.StatusCode()))
+	}
+}
+
+// waitForJob polls the job until it finishes in internal.CallbackModePoll.  In the other modes the job's
+// callback completes the activity.
+func (d *VideoInfoDeps) waitForJob(ctx context.Context, logger log.Logger, jobUUID uuid.UUID) (*VideoInfo, error) {
+	if d.CallbackMode != internal.CallbackModePoll {
+		// Will be completed asynchronously
+		return nil, activity.ErrResultPending
+	}
+	return d.pollUntilDone(ctx, logger, jobUUID)
+}
+
+// pollUntilDone polls the job until it finishes, returning its outcome.
+func (d *VideoInfoDeps) pollUntilDone(ctx context.Context, logger log.Logger, jobUUID uuid.UUID) (*VideoInfo, error) {
+	var info *VideoInfo
+	err := pollJob(ctx, logger, d.PollInterval, func() (bool, error) {
+		var done bool
+		var err error
+		info, done, err = d.checkJob(ctx, jobUUID)
+		return done, err
+	})
+	return info, err
+}
+
+// resumeJob picks up a job that an earlier attempt of this activity created.  In internal.CallbackModeID
+// the job's callback completes this attempt, but the job may have finished and sent it to the earlier
+// attempt already, so its status is checked before waiting.  In the other modes the job's callback is
+// addressed to the earlier attempt's task token, so this attempt polls the job itself.
+func (d *VideoInfoDeps) resumeJob(ctx context.Context, logger log.Logger, jobUUID uuid.UUID) (*VideoInfo, error) {
+	if d.CallbackMode != internal.CallbackModeID {
+		return d.pollUntilDone(ctx, logger, jobUUID)
+	}
+	info, done, err := d.checkJob(ctx, jobUUID)
+	switch {
+	case done:
+		logger.Info("Job from an earlier attempt has already finished")
+		return info, err
+	case err != nil:
+		return nil, fmt.Errorf("%w: failed to check on job: %w", ErrGetVideoInfo, err)
+	}
+	return d.waitForJob(ctx, logger, jobUUID)
+}
+
+// checkJob fetches the job's status.  done reports whether the job has finished, in which case info and
+// err are its outcome; otherwise err is from fetching the status.
+func (d *VideoInfoDeps) checkJob(ctx context.Context, jobUUID uuid.UUID) (info *VideoInfo, done bool, err error) {
+	resp, err := d.Client.GetInfoStatusWithResponse(ctx, jobUUID)
+	switch {
+	case err != nil:
+		return nil, false, err
+	case resp.JSON404 != nil:
+		// The service has lost the job, so a retry has to start it again.
+		return nil, true, fmt.Errorf("%w: job disappeared: %s", ErrGetVideoInfo, resp.JSON404.Message)
+	case resp.JSON200 == nil:
+		return nil, false, fmt.Errorf("unexpected response status %d", resp.StatusCode())
+	}
+	activity.RecordHeartbeat(ctx)
+	job := resp.JSON200
+	switch job.Status {
+	case virest.Completed:
+		if job.Result == nil {
+			return nil, true, fmt.Errorf("%w: completed job has no result", ErrGetVideoInfo)
+		}
+		return &VideoInfo{
+			DurationSeconds:  job.Result.TotalDurationSeconds,
+			ChapterDurations: job.Result.ChapterDurationsSeconds,
+		}, true, nil
+	case virest.Failed:
+		return nil, true, JobFailedError(fmt.Sprintf("%s: %s", ErrGetVideoInfo, jobErrorMessage(job.Error)))
 	default:
-		return fmt.Errorf("%w: unexpected response status %d", ErrGetVideoInfo, resp.HTTPResponse.StatusCode)
+		return nil, false, nil
 	}
 }
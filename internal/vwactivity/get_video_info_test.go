@@ -0,0 +1,265 @@
+package vwactivity
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/krelinga/video-info/virest"
+	"github.com/krelinga/video-workflows/internal"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+)
+
+// fakeVideoInfoClient records the create request and answers it with resp and err, or with retryResp
+// after the first call if that is set.  Status polls are answered from statuses in order, repeating the
+// last one.
+type fakeVideoInfoClient struct {
+	virest.ClientWithResponsesInterface
+
+	resp      *virest.CreateInfoResponse
+	err       error
+	retryResp *virest.CreateInfoResponse
+	statuses  []infoStatus
+
+	calls       int
+	statusCalls int
+	req         virest.CreateInfoJSONRequestBody
+}
+
+type infoStatus struct {
+	resp *virest.GetInfoStatusResponse
+	err  error
+}
+
+func (c *fakeVideoInfoClient) CreateInfoWithResponse(ctx context.Context, body virest.CreateInfoJSONRequestBody, reqEditors ...virest.RequestEditorFn) (*virest.CreateInfoResponse, error) {
+	c.calls++
+	c.req = body
+	if c.calls > 1 && c.retryResp != nil {
+		return c.retryResp, nil
+	}
+	return c.resp, c.err
+}
+
+func (c *fakeVideoInfoClient) GetInfoStatusWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...virest.RequestEditorFn) (*virest.GetInfoStatusResponse, error) {
+	status := c.statuses[min(c.statusCalls, len(c.statuses)-1)]
+	c.statusCalls++
+	return status.resp, status.err
+}
+
+func infoJobStatus(status virest.InfoStatus, result *virest.VideoInfo, jobError *string) infoStatus {
+	return infoStatus{resp: &virest.GetInfoStatusResponse{
+		HTTPResponse: httpResponse(http.StatusOK),
+		JSON200:      &virest.InfoJob{Status: status, Result: result, Error: jobError},
+	}}
+}
+
+func (s *ActivityTestSuite) getVideoInfo(client *fakeVideoInfoClient) (*VideoInfo, error) {
+	deps := &VideoInfoDeps{Client: client, CallbackMode: s.callbackMode, PollInterval: testPollInterval}
+	s.env.RegisterActivity(deps)
+	val, err := s.env.ExecuteActivity(deps.GetVideoInfo, GetVideoInfoParams{
+		Uuid:               testJobUUID,
+		VideoPath:          testVideoPath,
+		WebhookCompleteURI: testInfoURI,
+	})
+	s.Equal(1, client.calls)
+	if err != nil {
+		return nil, err
+	}
+	var info *VideoInfo
+	s.Require().NoError(val.Get(&info))
+	return info, nil
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_Accepted() {
+	client := &fakeVideoInfoClient{resp: &virest.CreateInfoResponse{
+		HTTPResponse: httpResponse(http.StatusCreated),
+		JSON201:      &virest.InfoJob{},
+	}}
+
+	_, err := s.getVideoInfo(client)
+
+	s.ErrorIs(err, activity.ErrResultPending)
+	s.Equal(testJobUUID, client.req.Uuid.String())
+	s.Equal(testVideoPath, client.req.VideoPath)
+	s.Require().NotNil(client.req.WebhookUri)
+	s.Equal(testInfoURI, *client.req.WebhookUri)
+	s.assertCallbackToken(client.req.WebhookToken)
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_AcceptedByID() {
+	s.callbackMode = internal.CallbackModeID
+	client := &fakeVideoInfoClient{resp: &virest.CreateInfoResponse{
+		HTTPResponse: httpResponse(http.StatusCreated),
+		JSON201:      &virest.InfoJob{},
+	}}
+
+	_, err := s.getVideoInfo(client)
+
+	s.ErrorIs(err, activity.ErrResultPending)
+	s.assertCallbackToken(client.req.WebhookToken)
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_ErrorResponses() {
+	serviceError := &virest.Error{Code: "CODE", Message: "service message"}
+	tests := []struct {
+		name      string
+		resp      *virest.CreateInfoResponse
+		errorType string
+	}{
+		{"bad request", &virest.CreateInfoResponse{HTTPResponse: httpResponse(http.StatusBadRequest), JSON400: serviceError}, ErrorTypeBadRequest},
+		{"conflict", &virest.CreateInfoResponse{HTTPResponse: httpResponse(http.StatusConflict), JSON409: serviceError}, ErrorTypeConflict},
+		{"internal error", &virest.CreateInfoResponse{HTTPResponse: httpResponse(http.StatusInternalServerError), JSON500: serviceError}, ""},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			_, err := s.getVideoInfo(&fakeVideoInfoClient{resp: tt.resp})
+			s.Require().Error(err)
+			s.NotErrorIs(err, activity.ErrResultPending)
+			s.Contains(err.Error(), ErrGetVideoInfo.Error())
+			s.Contains(err.Error(), serviceError.Message)
+			s.assertRetryable(err, tt.errorType)
+		})
+	}
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_UnexpectedStatus() {
+	_, err := s.getVideoInfo(&fakeVideoInfoClient{resp: &virest.CreateInfoResponse{
+		HTTPResponse: httpResponse(http.StatusBadGateway),
+	}})
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), ErrGetVideoInfo.Error())
+	s.Contains(err.Error(), "502")
+	s.assertRetryable(err, "")
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_TransportError() {
+	_, err := s.getVideoInfo(&fakeVideoInfoClient{err: errTransport})
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), ErrGetVideoInfo.Error())
+	s.Contains(err.Error(), errTransport.Error())
+	s.assertRetryable(err, "")
+}
+
+var testInfoAccepted = &virest.CreateInfoResponse{
+	HTTPResponse: httpResponse(http.StatusCreated),
+	JSON201:      &virest.InfoJob{},
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_Polled() {
+	s.callbackMode = internal.CallbackModePoll
+	client := &fakeVideoInfoClient{resp: testInfoAccepted, statuses: []infoStatus{
+		infoJobStatus(virest.Running, nil, nil),
+		{err: errTransport},
+		infoJobStatus(virest.Completed, &virest.VideoInfo{TotalDurationSeconds: 60, ChapterDurationsSeconds: []float64{20, 40}}, nil),
+	}}
+
+	info, err := s.getVideoInfo(client)
+
+	s.Require().NoError(err)
+	s.Equal(&VideoInfo{DurationSeconds: 60, ChapterDurations: []float64{20, 40}}, info)
+	s.Equal(3, client.statusCalls)
+	s.Nil(client.req.WebhookUri)
+	s.Empty(client.req.WebhookToken)
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_PolledJobFailed() {
+	s.callbackMode = internal.CallbackModePoll
+	jobError := "no video stream"
+	client := &fakeVideoInfoClient{resp: testInfoAccepted, statuses: []infoStatus{
+		infoJobStatus(virest.Failed, nil, &jobError),
+	}}
+
+	_, err := s.getVideoInfo(client)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), ErrGetVideoInfo.Error())
+	s.Contains(err.Error(), jobError)
+	s.assertRetryable(err, ErrorTypeJobFailed)
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_PolledJobLost() {
+	s.callbackMode = internal.CallbackModePoll
+	client := &fakeVideoInfoClient{resp: testInfoAccepted, statuses: []infoStatus{
+		{resp: &virest.GetInfoStatusResponse{
+			HTTPResponse: httpResponse(http.StatusNotFound),
+			JSON404:      &virest.Error{Code: "NOT_FOUND", Message: "no such job"},
+		}},
+	}}
+
+	_, err := s.getVideoInfo(client)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), "no such job")
+	s.assertRetryable(err, "")
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_RetryFindsFailedJob() {
+	// The first attempt's job failed and sent its callback to that attempt, so the retry must not wait
+	// for another one.
+	s.callbackMode = internal.CallbackModeID
+	jobError := "no video stream"
+	client := &fakeVideoInfoClient{
+		resp: &virest.CreateInfoResponse{HTTPResponse: httpResponse(http.StatusInternalServerError)},
+		retryResp: &virest.CreateInfoResponse{
+			HTTPResponse: httpResponse(http.StatusConflict),
+			JSON409:      &virest.Error{Code: "CONFLICT", Message: "exists"},
+		},
+		statuses: []infoStatus{infoJobStatus(virest.Failed, nil, &jobError)},
+	}
+	deps := &VideoInfoDeps{Client: client, CallbackMode: s.callbackMode}
+
+	err := s.executeRetried(deps, deps.GetVideoInfo, GetVideoInfoParams{Uuid: testJobUUID, VideoPath: testVideoPath})
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), jobError)
+	s.assertRetryable(err, ErrorTypeJobFailed)
+	s.Equal(2, client.calls)
+	s.Equal(1, client.statusCalls)
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_RetryFindsRunningJob() {
+	s.callbackMode = internal.CallbackModeID
+	client := &fakeVideoInfoClient{
+		resp: &virest.CreateInfoResponse{HTTPResponse: httpResponse(http.StatusInternalServerError)},
+		retryResp: &virest.CreateInfoResponse{
+			HTTPResponse: httpResponse(http.StatusConflict),
+			JSON409:      &virest.Error{Code: "CONFLICT", Message: "exists"},
+		},
+		statuses: []infoStatus{infoJobStatus(virest.Running, nil, nil)},
+	}
+	deps := &VideoInfoDeps{Client: client, CallbackMode: s.callbackMode}
+
+	err := s.executeRetried(deps, deps.GetVideoInfo, GetVideoInfoParams{Uuid: testJobUUID, VideoPath: testVideoPath})
+
+	// The retry waits for the running job's callback, which never comes here.
+	s.Require().Error(err)
+	var timeoutErr *temporal.TimeoutError
+	s.ErrorAs(err, &timeoutErr)
+	s.Equal(1, client.statusCalls)
+}
+
+func (s *ActivityTestSuite) Test_GetVideoInfo_TokenRetryPollsJob() {
+	s.callbackMode = internal.CallbackModeToken
+	client := &fakeVideoInfoClient{
+		resp: &virest.CreateInfoResponse{HTTPResponse: httpResponse(http.StatusInternalServerError)},
+		retryResp: &virest.CreateInfoResponse{
+			HTTPResponse: httpResponse(http.StatusConflict),
+			JSON409:      &virest.Error{Code: "CONFLICT", Message: "exists"},
+		},
+		statuses: []infoStatus{
+			infoJobStatus(virest.Running, nil, nil),
+			infoJobStatus(virest.Completed, &virest.VideoInfo{TotalDurationSeconds: 60}, nil),
+		},
+	}
+	deps := &VideoInfoDeps{Client: client, CallbackMode: s.callbackMode, PollInterval: testPollInterval}
+
+	err := s.executeRetried(deps, deps.GetVideoInfo, GetVideoInfoParams{Uuid: testJobUUID, VideoPath: testVideoPath})
+
+	s.NoError(err)
+	s.Equal(2, client.calls)
+	s.Equal(2, client.statusCalls)
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/krelinga/video-workflows/internal"
 )
 
 type ListVideoFilesParams struct {
@@ -14,6 +16,8 @@ type ListVideoFilesParams struct {
 
 type ListVideoFilesResult struct {
 	VideoPaths []string `json:"video_paths"`
+	// SizesBytes maps each of VideoPaths to the file's size.
+	SizesBytes map[string]int64 `json:"sizes_bytes,omitempty"`
 }
 
 func ListVideoFiles(ctx context.Context, params ListVideoFilesParams) (*ListVideoFilesResult, error) {
@@ -38,14 +42,22 @@ func ListVideoFiles(ctx context.Context, params ListVideoFilesParams) (*ListVide
 
 	// Filter for .mkv files
 	var videoPaths []string
+	sizes := make(map[string]int64)
 	for _, entry := range entries {
 		if !entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".mkv") {
 			videoPath := filepath.Join(params.DirectoryPath, entry.Name())
+			entryInfo, err := entry.Info()
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat %s: %w", videoPath, err)
+			}
 			videoPaths = append(videoPaths, videoPath)
+			sizes[videoPath] = entryInfo.Size()
 		}
 	}
 
+	getLogger(ctx, internal.LogKeyFilePath, params.DirectoryPath).Info("Listed video files", "count", len(videoPaths))
 	return &ListVideoFilesResult{
 		VideoPaths: videoPaths,
+		SizesBytes: sizes,
 	}, nil
 }
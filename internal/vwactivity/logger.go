@@ -0,0 +1,16 @@
+package vwactivity
+
+import (
+	"context"
+
+	"github.com/krelinga/video-workflows/internal"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/log"
+)
+
+// getLogger returns the activity logger with the disc UUID (the workflow ID of a disc workflow) and any
+// additional keyvals attached.
+func getLogger(ctx context.Context, keyvals ...any) log.Logger {
+	discUUID := activity.GetInfo(ctx).WorkflowExecution.ID
+	return log.With(activity.GetLogger(ctx), append([]any{internal.LogKeyDiscUUID, discUUID}, keyvals...)...)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+
+	"github.com/krelinga/video-workflows/internal"
 )
 
 type MkDirParams struct {
@@ -15,6 +17,7 @@ func MkDir(ctx context.Context, params MkDirParams) error {
 		return fmt.Errorf("path cannot be empty")
 	}
 
+	getLogger(ctx, internal.LogKeyFilePath, params.Path).Info("Creating directory")
 	if err := os.MkdirAll(params.Path, 0755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %w", params.Path, err)
 	}
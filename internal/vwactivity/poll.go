@@ -0,0 +1,45 @@
+package vwactivity
+
+import (
+	"context"
+	"time"
+
+	"github.com/krelinga/video-workflows/internal"
+	"go.temporal.io/sdk/log"
+)
+
+// defaultPollInterval is used when deps leave PollInterval unset.
+const defaultPollInterval = 10 * time.Second
+
+// pollJob calls poll every interval until poll reports that the job is done, and returns poll's error for
+// the finished job.  Errors from polls that do not finish the job are logged and polling carries on, since
+// the service may only be briefly unreachable; the activity's timeouts bound how long that can last.
+func pollJob(ctx context.Context, logger log.Logger, interval time.Duration, poll func() (bool, error)) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		done, err := poll()
+		if done {
+			return err
+		}
+		if err != nil {
+			logger.Warn("Failed to poll job status", internal.LogKeyError, err)
+		}
+	}
+}
+
+// jobErrorMessage returns the error a failed job reported, which services may leave out.
+func jobErrorMessage(msg *string) string {
+	if msg == nil || *msg == "" {
+		return "job failed without an error message"
+	}
+	return *msg
+}
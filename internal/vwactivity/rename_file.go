@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+
+	"github.com/krelinga/video-workflows/internal"
 )
 
 type RenameFileParams struct {
@@ -19,6 +21,7 @@ func RenameFile(ctx context.Context, params RenameFileParams) error {
 		return fmt.Errorf("target_path cannot be empty")
 	}
 
+	getLogger(ctx, internal.LogKeyFilePath, params.SourcePath).Info("Renaming file", "target_path", params.TargetPath)
 	if err := os.Rename(params.SourcePath, params.TargetPath); err != nil {
 		return fmt.Errorf("failed to rename file from %s to %s: %w", params.SourcePath, params.TargetPath, err)
 	}
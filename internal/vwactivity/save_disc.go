@@ -0,0 +1,34 @@
+package vwactivity
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/krelinga/video-workflows/internal/vwstore"
+)
+
+type SaveDiscParams struct {
+	Uuid string `json:"uuid"`
+	// Status is vwstore.StatusCompleted or vwstore.StatusFailed.
+	Status string  `json:"status"`
+	Error  *string `json:"error,omitempty"`
+	// State is the workflow's final state, encoded as JSON.
+	State json.RawMessage `json:"state"`
+}
+
+type StoreDeps struct {
+	Store *vwstore.Store
+}
+
+// SaveDisc records a disc workflow's outcome in the server's disc store.
+func (d *StoreDeps) SaveDisc(ctx context.Context, params SaveDiscParams) error {
+	getLogger(ctx).Info("Saving disc outcome", "status", params.Status)
+	return d.Store.Put(ctx, vwstore.Record{
+		UUID:     params.Uuid,
+		Status:   params.Status,
+		Error:    params.Error,
+		State:    params.State,
+		ClosedAt: time.Now(),
+	})
+}
@@ -2,11 +2,16 @@ package vwactivity
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/krelinga/video-transcoder/vtrest"
+	"github.com/krelinga/video-workflows/internal"
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/log"
 )
 
 type TranscodeParams struct {
@@ -24,21 +29,114 @@ type TranscodeProgress struct {
 
 type TranscodeDeps struct {
 	Client vtrest.ClientWithResponsesInterface
+	// CallbackMode is one of the internal.CallbackMode* values; empty means internal.CallbackModeToken.
+	CallbackMode string
+	// PollInterval is how often the job is polled in internal.CallbackModePoll.
+	PollInterval time.Duration
+}
+
+var ErrTranscode = errors.New("failed to transcode")
+
+func transcodeError(status int, errorMessage *vtrest.Error) error {
+	return dependencyError(status, fmt.Errorf("%w: %s", ErrTranscode, errorMessage.Message))
 }
 
 func (d *TranscodeDeps) Transcode(ctx context.Context, params TranscodeParams) error {
+	jobUUID := uuid.MustParse(params.Uuid)
 	req := vtrest.CreateTranscodeJSONRequestBody{
-		Uuid:                uuid.MustParse(params.Uuid),
-		SourcePath:          params.InputPath,
-		DestinationPath:     params.OutputPath,
-		Profile:             params.Profile,
-		WebhookToken:        activity.GetInfo(ctx).TaskToken,
-		WebhookUri:          &params.WebhookCompleteURI,
-		HeartbeatWebhookUri: &params.WebhookProgressURI,
-	}
-	_, err := d.Client.CreateTranscodeWithResponse(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to initiate transcoding: %w", err)
-	}
-	return activity.ErrResultPending
+		Uuid:            jobUUID,
+		SourcePath:      params.InputPath,
+		DestinationPath: params.OutputPath,
+		Profile:         params.Profile,
+	}
+	if d.CallbackMode != internal.CallbackModePoll {
+		token, err := newCallbackToken(ctx, d.CallbackMode, params.Uuid)
+		if err != nil {
+			return err
+		}
+		req.WebhookToken = token
+		req.WebhookUri = &params.WebhookCompleteURI
+		req.HeartbeatWebhookUri = &params.WebhookProgressURI
+	}
+	logger := getLogger(ctx, internal.LogKeyFilePath, params.InputPath, internal.LogKeyJobUUID, params.Uuid)
+	logger.Info("Requesting transcode", "output_path", params.OutputPath, "profile", params.Profile)
+	resp, err := d.Client.CreateTranscodeWithResponse(ctx, req)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%w: unexpected error: %s", ErrTranscode, err)
+	case resp.JSON201 != nil:
+		return d.waitForJob(ctx, logger, jobUUID)
+	case resp.JSON400 != nil:
+		return transcodeError(http.StatusBadRequest, resp.JSON400)
+	case resp.JSON409 != nil && jobFromEarlierAttempt(ctx):
+		logger.Info("Job already started by an earlier attempt, resuming it")
+		return d.resumeJob(ctx, logger, jobUUID)
+	case resp.JSON409 != nil:
+		return transcodeError(http.StatusConflict, resp.JSON409)
+	case resp.JSON500 != nil:
+		return transcodeError(http.StatusInternalServerError, resp.JSON500)
+	default:
+		return dependencyError(resp.StatusCode(), fmt.Errorf("%w: unexpected response status %d", ErrTranscode, resp.StatusCode()))
+	}
+}
+
+// waitForJob polls the job until it finishes in internal.CallbackModePoll, heartbeating its progress as
+// the transcoder's heartbeat webhook would.  In the other modes the job's callbacks complete the activity.
+func (d *TranscodeDeps) waitForJob(ctx context.Context, logger log.Logger, jobUUID uuid.UUID) error {
+	if d.CallbackMode != internal.CallbackModePoll {
+		// Will be completed asynchronously
+		return activity.ErrResultPending
+	}
+	return d.pollUntilDone(ctx, logger, jobUUID)
+}
+
+// pollUntilDone polls the job until it finishes, returning its outcome.
+func (d *TranscodeDeps) pollUntilDone(ctx context.Context, logger log.Logger, jobUUID uuid.UUID) error {
+	return pollJob(ctx, logger, d.PollInterval, func() (bool, error) {
+		return d.checkJob(ctx, jobUUID)
+	})
+}
+
+// resumeJob picks up a job that an earlier attempt of this activity created.  In internal.CallbackModeID
+// the job's callbacks complete this attempt, but the job may have finished and sent its completion to the
+// earlier attempt already, so its status is checked before waiting.  In the other modes the job's
+// callbacks are addressed to the earlier attempt's task token, so this attempt polls the job itself.
+func (d *TranscodeDeps) resumeJob(ctx context.Context, logger log.Logger, jobUUID uuid.UUID) error {
+	if d.CallbackMode != internal.CallbackModeID {
+		return d.pollUntilDone(ctx, logger, jobUUID)
+	}
+	done, err := d.checkJob(ctx, jobUUID)
+	switch {
+	case done:
+		logger.Info("Job from an earlier attempt has already finished")
+		return err
+	case err != nil:
+		return fmt.Errorf("%w: failed to check on job: %w", ErrTranscode, err)
+	}
+	return d.waitForJob(ctx, logger, jobUUID)
+}
+
+// checkJob fetches the job's status, heartbeating its progress.  done reports whether the job has
+// finished, in which case err is its outcome; otherwise err is from fetching the status.
+func (d *TranscodeDeps) checkJob(ctx context.Context, jobUUID uuid.UUID) (done bool, err error) {
+	resp, err := d.Client.GetTranscodeStatusWithResponse(ctx, jobUUID)
+	switch {
+	case err != nil:
+		return false, err
+	case resp.JSON404 != nil:
+		// The service has lost the job, so a retry has to start it again.
+		return true, fmt.Errorf("%w: job disappeared: %s", ErrTranscode, resp.JSON404.Message)
+	case resp.JSON200 == nil:
+		return false, fmt.Errorf("unexpected response status %d", resp.StatusCode())
+	}
+	job := resp.JSON200
+	activity.RecordHeartbeat(ctx, TranscodeProgress{Percentage: job.Progress})
+	switch job.Status {
+	case vtrest.Completed:
+		return true, nil
+	case vtrest.Failed:
+		return true, JobFailedError(fmt.Sprintf("%s: %s", ErrTranscode, jobErrorMessage(job.Error)))
+	default:
+		return false, nil
+	}
 }
@@ -0,0 +1,255 @@
+package vwactivity
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/krelinga/video-transcoder/vtrest"
+	"github.com/krelinga/video-workflows/internal"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"go.temporal.io/sdk/activity"
+)
+
+// fakeTranscodeClient records the create request and answers it with resp and err, or with retryResp
+// after the first call if that is set.  Status polls are answered from statuses in order, repeating the
+// last one.
+type fakeTranscodeClient struct {
+	vtrest.ClientWithResponsesInterface
+
+	resp      *vtrest.CreateTranscodeResponse
+	err       error
+	retryResp *vtrest.CreateTranscodeResponse
+	statuses  []*vtrest.GetTranscodeStatusResponse
+
+	calls       int
+	statusCalls int
+	req         vtrest.CreateTranscodeJSONRequestBody
+}
+
+func (c *fakeTranscodeClient) CreateTranscodeWithResponse(ctx context.Context, body vtrest.CreateTranscodeJSONRequestBody, reqEditors ...vtrest.RequestEditorFn) (*vtrest.CreateTranscodeResponse, error) {
+	c.calls++
+	c.req = body
+	if c.calls > 1 && c.retryResp != nil {
+		return c.retryResp, nil
+	}
+	return c.resp, c.err
+}
+
+func (c *fakeTranscodeClient) GetTranscodeStatusWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...vtrest.RequestEditorFn) (*vtrest.GetTranscodeStatusResponse, error) {
+	status := c.statuses[min(c.statusCalls, len(c.statuses)-1)]
+	c.statusCalls++
+	return status, nil
+}
+
+func transcodeJobStatus(status vtrest.TranscodeStatus, progress float64, jobError *string) *vtrest.GetTranscodeStatusResponse {
+	return &vtrest.GetTranscodeStatusResponse{
+		HTTPResponse: httpResponse(http.StatusOK),
+		JSON200:      &vtrest.TranscodeJob{Status: status, Progress: progress, Error: jobError},
+	}
+}
+
+func (s *ActivityTestSuite) transcode(client *fakeTranscodeClient) error {
+	deps := &TranscodeDeps{Client: client, CallbackMode: s.callbackMode, PollInterval: testPollInterval}
+	s.env.RegisterActivity(deps)
+	_, err := s.env.ExecuteActivity(deps.Transcode, TranscodeParams{
+		Uuid:               testJobUUID,
+		InputPath:          testVideoPath,
+		OutputPath:         testPreviewPath,
+		Profile:            "preview",
+		WebhookCompleteURI: testCompleteURI,
+		WebhookProgressURI: testProgressURI,
+	})
+	s.Equal(1, client.calls)
+	return err
+}
+
+func (s *ActivityTestSuite) Test_Transcode_Accepted() {
+	client := &fakeTranscodeClient{resp: &vtrest.CreateTranscodeResponse{
+		HTTPResponse: httpResponse(http.StatusCreated),
+		JSON201:      &vtrest.TranscodeJob{},
+	}}
+
+	err := s.transcode(client)
+
+	s.ErrorIs(err, activity.ErrResultPending)
+	s.Equal(testJobUUID, client.req.Uuid.String())
+	s.Equal(testVideoPath, client.req.SourcePath)
+	s.Equal(testPreviewPath, client.req.DestinationPath)
+	s.Equal("preview", client.req.Profile)
+	s.Require().NotNil(client.req.WebhookUri)
+	s.Equal(testCompleteURI, *client.req.WebhookUri)
+	s.Require().NotNil(client.req.HeartbeatWebhookUri)
+	s.Equal(testProgressURI, *client.req.HeartbeatWebhookUri)
+	s.assertCallbackToken(client.req.WebhookToken)
+}
+
+func (s *ActivityTestSuite) Test_Transcode_AcceptedByID() {
+	s.callbackMode = internal.CallbackModeID
+	client := &fakeTranscodeClient{resp: &vtrest.CreateTranscodeResponse{
+		HTTPResponse: httpResponse(http.StatusCreated),
+		JSON201:      &vtrest.TranscodeJob{},
+	}}
+
+	err := s.transcode(client)
+
+	s.ErrorIs(err, activity.ErrResultPending)
+	s.assertCallbackToken(client.req.WebhookToken)
+}
+
+func (s *ActivityTestSuite) Test_Transcode_ConflictByID() {
+	// On the first attempt a conflict cannot be a job this activity started, so it still fails.
+	s.callbackMode = internal.CallbackModeID
+	err := s.transcode(&fakeTranscodeClient{resp: &vtrest.CreateTranscodeResponse{
+		HTTPResponse: httpResponse(http.StatusConflict),
+		JSON409:      &vtrest.Error{Code: "CONFLICT", Message: "exists"},
+	}})
+
+	s.Require().Error(err)
+	s.assertRetryable(err, ErrorTypeConflict)
+}
+
+func (s *ActivityTestSuite) Test_Transcode_ErrorResponses() {
+	serviceError := &vtrest.Error{Code: "CODE", Message: "service message"}
+	tests := []struct {
+		name      string
+		resp      *vtrest.CreateTranscodeResponse
+		errorType string
+	}{
+		{"bad request", &vtrest.CreateTranscodeResponse{HTTPResponse: httpResponse(http.StatusBadRequest), JSON400: serviceError}, ErrorTypeBadRequest},
+		{"conflict", &vtrest.CreateTranscodeResponse{HTTPResponse: httpResponse(http.StatusConflict), JSON409: serviceError}, ErrorTypeConflict},
+		{"internal error", &vtrest.CreateTranscodeResponse{HTTPResponse: httpResponse(http.StatusInternalServerError), JSON500: serviceError}, ""},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			err := s.transcode(&fakeTranscodeClient{resp: tt.resp})
+			s.Require().Error(err)
+			s.NotErrorIs(err, activity.ErrResultPending)
+			s.Contains(err.Error(), ErrTranscode.Error())
+			s.Contains(err.Error(), serviceError.Message)
+			s.assertRetryable(err, tt.errorType)
+		})
+	}
+}
+
+func (s *ActivityTestSuite) Test_Transcode_UnexpectedStatus() {
+	err := s.transcode(&fakeTranscodeClient{resp: &vtrest.CreateTranscodeResponse{
+		HTTPResponse: httpResponse(http.StatusBadGateway),
+	}})
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), ErrTranscode.Error())
+	s.Contains(err.Error(), "502")
+	s.assertRetryable(err, "")
+}
+
+func (s *ActivityTestSuite) Test_Transcode_TransportError() {
+	err := s.transcode(&fakeTranscodeClient{err: errTransport})
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), ErrTranscode.Error())
+	s.Contains(err.Error(), errTransport.Error())
+	s.assertRetryable(err, "")
+}
+
+var testTranscodeAccepted = &vtrest.CreateTranscodeResponse{
+	HTTPResponse: httpResponse(http.StatusCreated),
+	JSON201:      &vtrest.TranscodeJob{},
+}
+
+func (s *ActivityTestSuite) Test_Transcode_Polled() {
+	s.callbackMode = internal.CallbackModePoll
+	client := &fakeTranscodeClient{resp: testTranscodeAccepted, statuses: []*vtrest.GetTranscodeStatusResponse{
+		transcodeJobStatus(vtrest.Pending, 0, nil),
+		transcodeJobStatus(vtrest.Running, 50, nil),
+		transcodeJobStatus(vtrest.Completed, 100, nil),
+	}}
+
+	err := s.transcode(client)
+
+	s.Require().NoError(err)
+	s.Equal(3, client.statusCalls)
+	s.Nil(client.req.WebhookUri)
+	s.Nil(client.req.HeartbeatWebhookUri)
+	s.Empty(client.req.WebhookToken)
+}
+
+func (s *ActivityTestSuite) Test_Transcode_PolledJobFailed() {
+	s.callbackMode = internal.CallbackModePoll
+	jobError := "encoder crashed"
+	client := &fakeTranscodeClient{resp: testTranscodeAccepted, statuses: []*vtrest.GetTranscodeStatusResponse{
+		transcodeJobStatus(vtrest.Running, 10, nil),
+		transcodeJobStatus(vtrest.Failed, 10, &jobError),
+	}}
+
+	err := s.transcode(client)
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), ErrTranscode.Error())
+	s.Contains(err.Error(), jobError)
+	s.assertRetryable(err, ErrorTypeJobFailed)
+}
+
+func (s *ActivityTestSuite) Test_Transcode_RetryFindsCompletedJob() {
+	s.callbackMode = internal.CallbackModeID
+	client := &fakeTranscodeClient{
+		err: errTransport,
+		retryResp: &vtrest.CreateTranscodeResponse{
+			HTTPResponse: httpResponse(http.StatusConflict),
+			JSON409:      &vtrest.Error{Code: "CONFLICT", Message: "exists"},
+		},
+		statuses: []*vtrest.GetTranscodeStatusResponse{transcodeJobStatus(vtrest.Completed, 100, nil)},
+	}
+	deps := &TranscodeDeps{Client: client, CallbackMode: s.callbackMode}
+
+	err := s.executeRetried(deps, deps.Transcode, TranscodeParams{Uuid: testJobUUID, InputPath: testVideoPath, OutputPath: testPreviewPath})
+
+	s.NoError(err)
+	s.Equal(2, client.calls)
+	s.Equal(1, client.statusCalls)
+}
+
+func (s *ActivityTestSuite) Test_Transcode_PolledRetryFindsFailedJob() {
+	// Polling the failed job again on every retry would only fail again, so it ends the activity.
+	s.callbackMode = internal.CallbackModePoll
+	jobError := "unsupported codec"
+	client := &fakeTranscodeClient{
+		err: errTransport,
+		retryResp: &vtrest.CreateTranscodeResponse{
+			HTTPResponse: httpResponse(http.StatusConflict),
+			JSON409:      &vtrest.Error{Code: "CONFLICT", Message: "exists"},
+		},
+		statuses: []*vtrest.GetTranscodeStatusResponse{transcodeJobStatus(vtrest.Failed, 40, &jobError)},
+	}
+	deps := &TranscodeDeps{Client: client, CallbackMode: s.callbackMode, PollInterval: testPollInterval}
+
+	err := s.executeRetried(deps, deps.Transcode, TranscodeParams{Uuid: testJobUUID, InputPath: testVideoPath, OutputPath: testPreviewPath})
+
+	s.Require().Error(err)
+	s.Contains(err.Error(), jobError)
+	s.assertRetryable(err, ErrorTypeJobFailed)
+	s.Equal(2, client.calls)
+	s.Equal(1, client.statusCalls)
+}
+
+func (s *ActivityTestSuite) Test_Transcode_TokenRetryPollsJob() {
+	// The job's callbacks go to the first attempt's task token, so the retry has to poll the job.
+	client := &fakeTranscodeClient{
+		err: errTransport,
+		retryResp: &vtrest.CreateTranscodeResponse{
+			HTTPResponse: httpResponse(http.StatusConflict),
+			JSON409:      &vtrest.Error{Code: "CONFLICT", Message: "exists"},
+		},
+		statuses: []*vtrest.GetTranscodeStatusResponse{
+			transcodeJobStatus(vtrest.Running, 40, nil),
+			transcodeJobStatus(vtrest.Completed, 100, nil),
+		},
+	}
+	deps := &TranscodeDeps{Client: client, CallbackMode: s.callbackMode, PollInterval: testPollInterval}
+
+	err := s.executeRetried(deps, deps.Transcode, TranscodeParams{Uuid: testJobUUID, InputPath: testVideoPath, OutputPath: testPreviewPath})
+
+	s.NoError(err)
+	s.Equal(2, client.calls)
+	s.Equal(2, client.statusCalls)
+}
@@ -0,0 +1,124 @@
+// Package vwstore keeps the final outcome of each disc workflow in a local SQLite database, so that it
+// can still be reported after Temporal has purged the workflow's history.
+package vwstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// Statuses of a stored disc.
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+var ErrNotFound = errors.New("disc not found in store")
+
+// Record is the outcome of a disc workflow that has closed.
+type Record struct {
+	UUID   string
+	Status string
+	// Error is the workflow's error, if it failed.
+	Error *string
+	// State is the workflow's final state, encoded as JSON.
+	State    json.RawMessage
+	ClosedAt time.Time
+}
+
+type Store struct {
+	db *sql.DB
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS discs (
+	uuid      TEXT PRIMARY KEY,
+	status    TEXT NOT NULL,
+	error     TEXT,
+	state     TEXT NOT NULL,
+	closed_at INTEGER NOT NULL
+)`
+
+// Open opens the database at path, creating it if needed.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open disc store: %w", err)
+	}
+	// SQLite allows one writer at a time; a single connection avoids "database is locked" errors.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create disc store schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put saves record, replacing any earlier record for the same disc.
+func (s *Store) Put(ctx context.Context, record Record) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO discs (uuid, status, error, state, closed_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (uuid) DO UPDATE SET
+			status = excluded.status, error = excluded.error, state = excluded.state, closed_at = excluded.closed_at`,
+		record.UUID, record.Status, record.Error, string(record.State), record.ClosedAt.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to save disc %s: %w", record.UUID, err)
+	}
+	return nil
+}
+
+// Get returns the record for a disc, or ErrNotFound.
+func (s *Store) Get(ctx context.Context, uuid string) (Record, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT uuid, status, error, state, closed_at FROM discs WHERE uuid = ?`, uuid)
+	record, err := scanRecord(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Record{}, fmt.Errorf("%w: %s", ErrNotFound, uuid)
+	}
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to load disc %s: %w", uuid, err)
+	}
+	return record, nil
+}
+
+// List returns every stored disc, most recently closed first.
+func (s *Store) List(ctx context.Context) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT uuid, status, error, state, closed_at FROM discs ORDER BY closed_at DESC, uuid`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discs: %w", err)
+	}
+	defer rows.Close()
+	var records []Record
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list discs: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list discs: %w", err)
+	}
+	return records, nil
+}
+
+func scanRecord(row interface{ Scan(...any) error }) (Record, error) {
+	var record Record
+	var state string
+	var closedAt int64
+	if err := row.Scan(&record.UUID, &record.Status, &record.Error, &state, &closedAt); err != nil {
+		return Record{}, err
+	}
+	record.State = json.RawMessage(state)
+	record.ClosedAt = time.UnixMilli(closedAt)
+	return record, nil
+}
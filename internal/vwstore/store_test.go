@@ -0,0 +1,77 @@
+package vwstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "discs.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+	errorMessage := "failed to move directory"
+	older := Record{UUID: "disc-1", Status: StatusFailed, Error: &errorMessage, State: json.RawMessage(`{}`), ClosedAt: time.UnixMilli(1000)}
+	newer := Record{UUID: "disc-2", Status: StatusCompleted, State: json.RawMessage(`{"files_listed":true}`), ClosedAt: time.UnixMilli(2000)}
+	for _, record := range []Record{older, newer} {
+		if err := store.Put(ctx, record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := store.Get(ctx, "disc-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != StatusCompleted || got.Error != nil || string(got.State) != `{"files_listed":true}` || !got.ClosedAt.Equal(newer.ClosedAt) {
+		t.Errorf("unexpected record %+v", got)
+	}
+
+	records, err := store.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].UUID != "disc-2" || records[1].UUID != "disc-1" {
+		t.Fatalf("expected discs newest first, got %+v", records)
+	}
+	if records[1].Error == nil || *records[1].Error != errorMessage {
+		t.Errorf("expected the failed disc's error, got %v", records[1].Error)
+	}
+
+	if _, err := store.Get(ctx, "disc-3"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStore_PutReplaces(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+	record := Record{UUID: "disc-1", Status: StatusFailed, State: json.RawMessage(`{}`), ClosedAt: time.UnixMilli(1000)}
+	if err := store.Put(ctx, record); err != nil {
+		t.Fatal(err)
+	}
+	record.Status = StatusCompleted
+	if err := store.Put(ctx, record); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Get(ctx, "disc-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != StatusCompleted {
+		t.Errorf("expected the record to be replaced, got status %q", got.Status)
+	}
+}
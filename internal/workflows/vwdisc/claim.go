@@ -0,0 +1,51 @@
+package vwdisc
+
+import (
+	"path/filepath"
+
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/krelinga/video-workflows/internal"
+)
+
+// Two discs created from the same inbox directory would both try to move it into the library.  To stop
+// that, the server claims the directory before starting a disc by starting ClaimSource with the ID
+// SourceClaimID(path): Temporal allows only one running workflow per ID, so a second claim on the same
+// directory fails until the first is released.  The disc releases its claim once the directory has been
+// moved, leaving the inbox path free to be reused, or when it ends without moving it.
+
+// MemoClaimDiscUUID is the memo field of a ClaimSource execution that holds the UUID of the disc that
+// made the claim.
+const MemoClaimDiscUUID = "disc_uuid"
+
+// SignalReleaseSource ends a ClaimSource execution.  It carries no payload.
+const SignalReleaseSource = "release-source"
+
+// SourceClaimID returns the workflow ID under which ClaimSource claims the inbox directory at path.
+func SourceClaimID(path string) string {
+	return "source:" + filepath.Clean(path)
+}
+
+// ClaimSource holds the claim on a disc's inbox directory until it receives SignalReleaseSource.
+func ClaimSource(ctx workflow.Context) error {
+	workflow.GetSignalChannel(ctx, SignalReleaseSource).Receive(ctx, nil)
+	return nil
+}
+
+// releaseSource releases the claim that the server made on params.Path before starting the disc.  A
+// failure to release it is logged rather than changing the outcome; the server treats claims held by
+// closed discs as stale.
+func releaseSource(ctx workflow.Context, logger log.Logger, params Params) {
+	version := workflow.GetVersion(ctx, changeReleaseSource, workflow.DefaultVersion, releaseSourceVersion)
+	if version == workflow.DefaultVersion || params.SourceClaimID == "" {
+		return
+	}
+	// Release the claim even if the disc was cancelled, since nothing else will.
+	releaseCtx, cancel := workflow.NewDisconnectedContext(ctx)
+	defer cancel()
+	err := workflow.SignalExternalWorkflow(releaseCtx, params.SourceClaimID, "", SignalReleaseSource, nil).Get(releaseCtx, nil)
+	if err != nil {
+		logger.Error("Failed to release source claim", internal.LogKeyFilePath, params.Path, internal.LogKeyError, err)
+	}
+}
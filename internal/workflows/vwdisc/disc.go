@@ -1,15 +1,22 @@
 package vwdisc
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"time"
 
+	"go.temporal.io/sdk/log"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 
 	"github.com/google/uuid"
+	"github.com/krelinga/video-workflows/internal"
 	"github.com/krelinga/video-workflows/internal/vwactivity"
+	"github.com/krelinga/video-workflows/internal/vwstore"
 )
 
 type Params struct {
@@ -18,24 +25,164 @@ type Params struct {
 	LibraryPath    string `json:"library_path"`
 	PreviewPath    string `json:"preview_path"`
 	WebhookBaseURI string `json:"webhook_base_uri"`
+	// FilesystemTaskQueue receives activities that touch the media filesystem.  Defaults to
+	// internal.DefaultFilesystemTaskQueue.
+	FilesystemTaskQueue string `json:"filesystem_task_queue,omitempty"`
+	// RemoteTaskQueue receives activities that start jobs on the video-info and transcoder services.
+	// Defaults to internal.DefaultRemoteTaskQueue.
+	RemoteTaskQueue string `json:"remote_task_queue,omitempty"`
+	// MaxConcurrentPreviews caps how many preview transcodes this disc has in flight at once.  Defaults
+	// to DefaultMaxConcurrentPreviews.
+	MaxConcurrentPreviews int `json:"max_concurrent_previews,omitempty"`
+	// StoreTaskQueue receives the activity that records the disc's outcome in the server's disc store.
+	// Empty means the outcome is not recorded.
+	StoreTaskQueue string `json:"store_task_queue,omitempty"`
+	// StartStage reprocesses a disc from the given stage, skipping the stages before it and carrying
+	// their results over from PreviousState, the state that an earlier run of the disc ended with.
+	// Empty runs every stage, and Path is only used in that case.
+	StartStage    Stage  `json:"start_stage,omitempty"`
+	PreviousState *State `json:"previous_state,omitempty"`
+	// SourceClaimID is the ClaimSource execution that claims Path for this disc.  It is released once
+	// Path has been moved.  Empty means Path is not claimed.
+	SourceClaimID string `json:"source_claim_id,omitempty"`
 }
 
+// Stage is a step of Workflow that a disc can be reprocessed from.
+type Stage string
+
+const (
+	StageListFiles   Stage = "list_files"
+	StageDiagnostics Stage = "diagnostics"
+	// StageFinalTranscode transcodes the main title again.  Workflow does not transcode the main title
+	// yet, so no disc can be reprocessed from this stage until it does.
+	StageFinalTranscode Stage = "final_transcode"
+)
+
+// CanStartAt reports whether a disc whose previous run ended in state can be reprocessed from stage,
+// which needs the results of every stage before it.
+func (s State) CanStartAt(stage Stage) bool {
+	switch stage {
+	case StageListFiles:
+		return s.reached(PhaseDirectoryMoved) && s.Directory != ""
+	case StageDiagnostics:
+		return s.reached(PhaseFilesListed) && s.Directory != ""
+	case StageFinalTranscode:
+		// TODO: Allow this once Workflow transcodes the main title and records a phase for it.
+		return false
+	default:
+		return false
+	}
+}
+
+// carryOver copies the results of the stages before stage from previous, and moves to the phase that
+// the last of them reached.  Results of stage itself and later ones are left out so that they are
+// produced afresh, apart from file categories, which the user assigns.
+func (s *State) carryOver(previous State, stage Stage) {
+	s.Directory = previous.Directory
+	s.Phase = PhaseDirectoryMoved
+	s.PhaseEnteredAt[PhaseDirectoryMoved] = previous.PhaseEnteredAt[PhaseDirectoryMoved]
+	if stage == StageListFiles {
+		return
+	}
+	s.Files = make(map[string]FileState, len(previous.Files))
+	for videoPath, fileState := range previous.Files {
+		s.Files[videoPath] = FileState{
+			SizeBytes: fileState.SizeBytes,
+			Category:  fileState.Category,
+		}
+	}
+	s.Phase = PhaseFilesListed
+	s.PhaseEnteredAt[PhaseFilesListed] = previous.PhaseEnteredAt[PhaseFilesListed]
+}
+
+// DefaultMaxConcurrentPreviews is used when Params.MaxConcurrentPreviews is not set.
+const DefaultMaxConcurrentPreviews = 2
+
 type State struct {
-	DirectoryMoved     bool                 `json:"directory_moved"`
-	Files              map[string]FileState `json:"files,omitempty"`
-	FilesListed        bool                 `json:"files_listed"`
-	GotFileDiagnostics bool                 `json:"got_file_diagnostics"`
+	Phase Phase `json:"phase"`
+	// Directory is where the disc's files are once it has been moved into the library.
+	Directory string               `json:"directory,omitempty"`
+	Files     map[string]FileState `json:"files,omitempty"`
+	// PhaseEnteredAt records when the workflow entered each phase it has reached.
+	PhaseEnteredAt map[Phase]time.Time `json:"phase_entered_at,omitempty"`
+	// PausedFrom is the phase the workflow is in while Phase is PhasePaused.
+	PausedFrom Phase `json:"paused_from,omitempty"`
+}
+
+// Phase is how far a disc has got through Workflow.  Phases other than PhasePaused are entered in the
+// order listed.
+type Phase string
+
+const (
+	PhaseRunning            Phase = "running"
+	PhaseDirectoryMoved     Phase = "directory_moved"
+	PhaseFilesListed        Phase = "files_listed"
+	PhaseGotFileDiagnostics Phase = "got_file_diagnostics"
+	// PhasePaused replaces the current phase between SignalPause and SignalResume.  Phases reached
+	// while paused are recorded in PausedFrom, and take effect on resume.
+	PhasePaused Phase = "paused"
+)
+
+// enterPhase moves the state to phase, recording when it did so.
+func (s *State) enterPhase(ctx workflow.Context, phase Phase) {
+	if s.PhaseEnteredAt == nil {
+		s.PhaseEnteredAt = make(map[Phase]time.Time)
+	}
+	s.PhaseEnteredAt[phase] = workflow.Now(ctx)
+	if s.Phase == PhasePaused && phase != PhasePaused {
+		s.PausedFrom = phase
+		return
+	}
+	s.Phase = phase
+}
+
+// reached reports whether the workflow has entered phase.
+func (s State) reached(phase Phase) bool {
+	_, ok := s.PhaseEnteredAt[phase]
+	return ok
+}
+
+func (s *State) paused() bool {
+	return s.Phase == PhasePaused
+}
+
+func (s *State) pause(ctx workflow.Context) {
+	if s.paused() {
+		return
+	}
+	s.PausedFrom = s.Phase
+	s.enterPhase(ctx, PhasePaused)
+}
+
+func (s *State) resume() {
+	if !s.paused() {
+		return
+	}
+	s.Phase = s.PausedFrom
+	s.PausedFrom = ""
 }
 
 type FileState struct {
+	SizeBytes               *int64        `json:"size_bytes,omitempty"`
 	DurationSeconds         *float64      `json:"duration_seconds,omitempty"`
 	ChapterDurationsSeconds []float64     `json:"chapter_durations_seconds,omitempty"`
 	PreviewPath             *string       `json:"preview_path,omitempty"`
 	Category                *FileCategory `json:"category,omitempty"`
 	PreviewError            *string       `json:"preview_error,omitempty"`
 	InfoError               *string       `json:"info_error,omitempty"`
+	PreviewStatus           PreviewStatus `json:"preview_status,omitempty"`
 }
 
+// PreviewStatus tracks a file's preview transcode through the scheduling window.
+type PreviewStatus string
+
+const (
+	PreviewStatusQueued  PreviewStatus = "queued"
+	PreviewStatusRunning PreviewStatus = "running"
+	PreviewStatusDone    PreviewStatus = "done"
+	PreviewStatusFailed  PreviewStatus = "failed"
+)
+
 type FileCategory string
 
 const (
@@ -46,77 +193,248 @@ const (
 
 const QueryGetState = "GetState"
 
-func Workflow(ctx workflow.Context, params Params) (State, error) {
-	// Set up state and an associated query handler.
+// SignalPause stops Workflow from starting any more activities until it receives SignalResume.
+// Activities that are already running are left to finish.  Neither signal carries a payload, and
+// repeating either one has no further effect.
+const (
+	SignalPause  = "pause"
+	SignalResume = "resume"
+)
+
+// handlePauseSignals applies SignalPause and SignalResume to state as they arrive.
+func handlePauseSignals(ctx workflow.Context, state *State) {
+	pauseChan := workflow.GetSignalChannel(ctx, SignalPause)
+	resumeChan := workflow.GetSignalChannel(ctx, SignalResume)
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		for {
+			selector := workflow.NewSelector(ctx)
+			selector.AddReceive(pauseChan, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				state.pause(ctx)
+			})
+			selector.AddReceive(resumeChan, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				state.resume()
+			})
+			selector.Select(ctx)
+		}
+	})
+}
+
+// waitWhilePaused blocks until state is not paused.  Workflow calls it before starting each activity.
+func waitWhilePaused(ctx workflow.Context, state *State) error {
+	if err := workflow.Await(ctx, func() bool { return !state.paused() }); err != nil {
+		return fmt.Errorf("interrupted while paused: %w", err)
+	}
+	return nil
+}
+
+// ErrorTypeDiscFailed is the type of the ApplicationError that Workflow fails with.  Its details hold the
+// State the workflow had reached, so callers can see what succeeded before the failure.
+const ErrorTypeDiscFailed = "DiscFailed"
+
+// failedWithState wraps a workflow failure so that it carries state.
+func failedWithState(err error, state State) error {
+	return temporal.NewApplicationErrorWithCause(err.Error(), ErrorTypeDiscFailed, err, state)
+}
+
+// StateFromError recovers the State carried by an error that Workflow failed with.  It returns false if
+// err does not carry one, as is the case for workflows that were terminated, cancelled or timed out.
+func StateFromError(err error) (State, bool) {
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) || appErr.Type() != ErrorTypeDiscFailed || !appErr.HasDetails() {
+		return State{}, false
+	}
 	var state State
+	if err := appErr.Details(&state); err != nil {
+		return State{}, false
+	}
+	return state, true
+}
+
+// remoteRetryPolicy applies to activities that start jobs on the video-info and transcoder services.
+// Rejected requests fail immediately, while server and transport errors are retried with backoff for a
+// bounded number of attempts so that a broken dependency cannot hold a file up forever.
+var remoteRetryPolicy = &temporal.RetryPolicy{
+	InitialInterval:        5 * time.Second,
+	BackoffCoefficient:     2,
+	MaximumInterval:        time.Minute,
+	MaximumAttempts:        5,
+	NonRetryableErrorTypes: vwactivity.NonRetryableErrorTypes,
+}
+
+// Transcodes report progress through heartbeat callbacks, so a transcoder that dies is noticed after
+// transcodeHeartbeatTimeout instead of at the end of the start-to-close window.  That window scales
+// with the length of the title, at the slowest speed (relative to real time) that a transcode of its
+// profile is expected to run.
+const (
+	transcodeHeartbeatTimeout = 2 * time.Minute
+	minTranscodeTimeout       = 10 * time.Minute
+	maxTranscodeTimeout       = 24 * time.Hour
+	previewTranscodeSpeed     = 1.0
+	mainTitleTranscodeSpeed   = 0.25
+)
+
+// transcodeRetryPolicy gives a transcode fewer, more widely spaced attempts than remoteRetryPolicy,
+// since each attempt can tie up a transcoder for a long time.
+var transcodeRetryPolicy = &temporal.RetryPolicy{
+	InitialInterval:        30 * time.Second,
+	BackoffCoefficient:     2,
+	MaximumInterval:        10 * time.Minute,
+	MaximumAttempts:        3,
+	NonRetryableErrorTypes: vwactivity.NonRetryableErrorTypes,
+}
+
+// transcodeActivityOptions returns the options for transcoding a title of the given duration at speed.
+// Titles whose duration is unknown get maxTranscodeTimeout.
+func transcodeActivityOptions(taskQueue string, durationSeconds *float64, speed float64) workflow.ActivityOptions {
+	timeout := maxTranscodeTimeout
+	if durationSeconds != nil && *durationSeconds > 0 {
+		timeout = min(minTranscodeTimeout+time.Duration(*durationSeconds/speed*float64(time.Second)), maxTranscodeTimeout)
+	}
+	return workflow.ActivityOptions{
+		TaskQueue:           taskQueue,
+		StartToCloseTimeout: timeout,
+		HeartbeatTimeout:    transcodeHeartbeatTimeout,
+		RetryPolicy:         transcodeRetryPolicy,
+	}
+}
+
+func Workflow(ctx workflow.Context, params Params) (state State, err error) {
+	logger := log.With(workflow.GetLogger(ctx), internal.LogKeyDiscUUID, params.UUID)
+	sourceReleased := params.StartStage != ""
+	defer func() {
+		if !sourceReleased {
+			releaseSource(ctx, logger, params)
+		}
+		// Nothing is left to start once the workflow ends, so it ends in the phase it reached.
+		state.resume()
+		if err != nil {
+			err = failedWithState(err, state)
+		}
+		saveResult(ctx, logger, params, state, err)
+	}()
+
+	// Set up state and an associated query handler.
+	state.enterPhase(ctx, PhaseRunning)
 	stateQuery := func() (State, error) {
 		return state, nil
 	}
 	if err := workflow.SetQueryHandler(ctx, QueryGetState, stateQuery); err != nil {
 		return state, fmt.Errorf("failed to set query handler: %w", err)
 	}
+	handlePauseSignals(ctx, &state)
+	if params.StartStage != "" {
+		if params.PreviousState == nil || !params.PreviousState.CanStartAt(params.StartStage) {
+			return state, fmt.Errorf("cannot reprocess disc from stage %q", params.StartStage)
+		}
+		logger.Info("Reprocessing disc", "stage", params.StartStage)
+		state.carryOver(*params.PreviousState, params.StartStage)
+	}
 
-	// Move the directory.
-	libraryPath := filepath.Join(params.LibraryPath, params.UUID)
-	renameFileOptions := workflow.ActivityOptions{
-		StartToCloseTimeout: 10 * time.Second,
-		RetryPolicy: &temporal.RetryPolicy{
-			MaximumAttempts: 3,
-		},
+	filesystemTaskQueue := params.FilesystemTaskQueue
+	if filesystemTaskQueue == "" {
+		filesystemTaskQueue = internal.DefaultFilesystemTaskQueue
 	}
-	renameFileParams := vwactivity.RenameFileParams{
-		SourcePath: params.Path,
-		TargetPath: libraryPath,
+	remoteTaskQueue := params.RemoteTaskQueue
+	if remoteTaskQueue == "" {
+		remoteTaskQueue = internal.DefaultRemoteTaskQueue
 	}
-	renameFileCtx := workflow.WithActivityOptions(ctx, renameFileOptions)
-	if err := workflow.ExecuteActivity(renameFileCtx, vwactivity.RenameFile, renameFileParams).Get(renameFileCtx, nil); err != nil {
-		return state, fmt.Errorf("failed to move directory: %w", err)
+
+	// Move the directory.
+	libraryPath := filepath.Join(params.LibraryPath, params.UUID)
+	if params.StartStage == "" {
+		workflow.GetVersion(ctx, changeMoveDirectory, workflow.DefaultVersion, moveDirectoryVersion)
+		renameFileOptions := workflow.ActivityOptions{
+			TaskQueue:           filesystemTaskQueue,
+			StartToCloseTimeout: 10 * time.Second,
+			RetryPolicy: &temporal.RetryPolicy{
+				MaximumAttempts: 3,
+			},
+		}
+		renameFileParams := vwactivity.RenameFileParams{
+			SourcePath: params.Path,
+			TargetPath: libraryPath,
+		}
+		renameFileCtx := workflow.WithActivityOptions(ctx, renameFileOptions)
+		if err := waitWhilePaused(ctx, &state); err != nil {
+			return state, err
+		}
+		if err := workflow.ExecuteActivity(renameFileCtx, vwactivity.RenameFile, renameFileParams).Get(renameFileCtx, nil); err != nil {
+			return state, fmt.Errorf("failed to move directory: %w", err)
+		}
+		state.Directory = libraryPath
+		state.enterPhase(ctx, PhaseDirectoryMoved)
+		releaseSource(ctx, logger, params)
+		sourceReleased = true
 	}
-	state.DirectoryMoved = true
 
 	// List all the files in the renamed directory and create corresponding state entries.
-	listVideoFilesCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-		StartToCloseTimeout: 30 * time.Second,
-	})
-	listVideoFilesParams := vwactivity.ListVideoFilesParams{
-		DirectoryPath: libraryPath,
-	}
-	logger := workflow.GetLogger(ctx)
-	logger.Info("Listing video files", "directory", listVideoFilesParams.DirectoryPath)
-	var listVideoFilesResult vwactivity.ListVideoFilesResult
-	if err := workflow.ExecuteActivity(listVideoFilesCtx, vwactivity.ListVideoFiles, listVideoFilesParams).Get(listVideoFilesCtx, &listVideoFilesResult); err != nil {
-		return state, fmt.Errorf("failed to list video files: %w", err)
-	}
-	logger.Info("Listed video files", "files", listVideoFilesResult.VideoPaths)
-	state.Files = make(map[string]FileState)
-	for _, videoPath := range listVideoFilesResult.VideoPaths {
-		state.Files[videoPath] = FileState{}
+	if params.StartStage == "" || params.StartStage == StageListFiles {
+		workflow.GetVersion(ctx, changeListFiles, workflow.DefaultVersion, listFilesVersion)
+		listVideoFilesCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			TaskQueue:           filesystemTaskQueue,
+			StartToCloseTimeout: 30 * time.Second,
+		})
+		listVideoFilesParams := vwactivity.ListVideoFilesParams{
+			DirectoryPath: libraryPath,
+		}
+		logger.Info("Listing video files", internal.LogKeyFilePath, listVideoFilesParams.DirectoryPath)
+		var listVideoFilesResult vwactivity.ListVideoFilesResult
+		if err := waitWhilePaused(ctx, &state); err != nil {
+			return state, err
+		}
+		if err := workflow.ExecuteActivity(listVideoFilesCtx, vwactivity.ListVideoFiles, listVideoFilesParams).Get(listVideoFilesCtx, &listVideoFilesResult); err != nil {
+			return state, fmt.Errorf("failed to list video files: %w", err)
+		}
+		logger.Info("Listed video files", "files", listVideoFilesResult.VideoPaths)
+		state.Files = make(map[string]FileState)
+		for _, videoPath := range listVideoFilesResult.VideoPaths {
+			var fileState FileState
+			if size, ok := listVideoFilesResult.SizesBytes[videoPath]; ok {
+				fileState.SizeBytes = &size
+			}
+			state.Files[videoPath] = fileState
+		}
+		state.enterPhase(ctx, PhaseFilesListed)
 	}
-	state.FilesListed = true
 
 	// Create preview directory
+	workflow.GetVersion(ctx, changePreviewDir, workflow.DefaultVersion, previewDirVersion)
 	makePreviewDirCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		TaskQueue:           filesystemTaskQueue,
 		StartToCloseTimeout: 10 * time.Second,
 	})
 	previewDir := filepath.Join(params.PreviewPath, params.UUID)
 	makePreviewDirParams := vwactivity.MkDirParams{
 		Path: previewDir,
 	}
+	if err := waitWhilePaused(ctx, &state); err != nil {
+		return state, err
+	}
 	if err := workflow.ExecuteActivity(makePreviewDirCtx, vwactivity.MkDir, makePreviewDirParams).Get(makePreviewDirCtx, nil); err != nil {
-		logger.Error("Failed to create preview directory", "path", previewDir, "error", err)
+		logger.Error("Failed to create preview directory", internal.LogKeyFilePath, previewDir, internal.LogKeyError, err)
 		return state, fmt.Errorf("failed to create preview directory: %w", err)
 	}
 
-	// For each file, get it's info & start generating a preview.  Update status.
+	// For each file, get it's info & generate a preview.  Info requests all start at once, but previews
+	// are started through a window of at most maxPreviews, longest titles first.  Update status.
+	workflow.GetVersion(ctx, changeDiagnostics, workflow.DefaultVersion, diagnosticsVersion)
+	maxPreviews := params.MaxConcurrentPreviews
+	if maxPreviews <= 0 {
+		maxPreviews = DefaultMaxConcurrentPreviews
+	}
 	getVideoInfoCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		TaskQueue:           remoteTaskQueue,
 		StartToCloseTimeout: 2 * time.Minute,
-	})
-	generatePreviewCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-		StartToCloseTimeout: 10 * time.Minute,
+		RetryPolicy:         remoteRetryPolicy,
 	})
 	diagSelect := workflow.NewSelector(ctx)
 	diagCount := 0
-	for videoPath := range state.Files {
+	for _, videoPath := range sortedPaths(state.Files) {
+		if err := waitWhilePaused(ctx, &state); err != nil {
+			return state, err
+		}
 		var videoInfoUuid string
 		if err := workflow.SideEffect(ctx, newUUID).Get(&videoInfoUuid); err != nil {
 			return state, fmt.Errorf("failed to generate UUID for video info activity: %w", err)
@@ -132,63 +450,185 @@ func Workflow(ctx workflow.Context, params Params) (State, error) {
 		diagSelect.AddFuture(videoInfoFuture, func(f workflow.Future) {
 			var info vwactivity.VideoInfo
 			err := f.Get(getVideoInfoCtx, &info)
+			fileState := state.Files[videoPath]
 			if err != nil {
-				logger.Error("Failed to get video info", "videoPath", videoPath, "error", err)
-				return
+				logger.Error("Failed to get video info", internal.LogKeyFilePath, videoPath, internal.LogKeyError, err)
+				errorMessage := err.Error()
+				fileState.InfoError = &errorMessage
+			} else {
+				fileState.DurationSeconds = &info.DurationSeconds
+				fileState.ChapterDurationsSeconds = info.ChapterDurations
 			}
-			fileState := state.Files[videoPath]
-			fileState.DurationSeconds = &info.DurationSeconds
-			fileState.ChapterDurationsSeconds = info.ChapterDurations
 			state.Files[videoPath] = fileState
 		})
 
-		var previewUuid string
-		if err := workflow.SideEffect(ctx, newUUID).Get(&previewUuid); err != nil {
-			return state, fmt.Errorf("failed to generate UUID for preview activity: %w", err)
-		}
-		videoExt := filepath.Ext(videoPath)
-		var previewBase string
-		if videoExt == "" {
-			previewBase = filepath.Base(videoPath) + ".mp4"
-		} else {
-			previewBase = filepath.Base(videoPath[0:len(videoPath)-len(videoExt)]) + ".mp4"
-		}
-		previewParams := vwactivity.TranscodeParams{
-			Uuid:               previewUuid,
-			InputPath:          videoPath,
-			OutputPath:         filepath.Join(previewDir, previewBase),
-			Profile:            "preview",
-			WebhookCompleteURI: params.WebhookBaseURI + "/transcode/complete",
-			WebhookProgressURI: params.WebhookBaseURI + "/transcode/progress",
-		}
-		var transcodeDeps *vwactivity.TranscodeDeps
-		previewFuture := workflow.ExecuteActivity(generatePreviewCtx, transcodeDeps.Transcode, previewParams)
-		diagCount++
-		diagSelect.AddFuture(previewFuture, func(f workflow.Future) {
-			err := f.Get(generatePreviewCtx, nil)
-			if err != nil {
-				logger.Error("Failed to generate preview", "videoPath", videoPath, "error", err)
-				return
+		fileState := state.Files[videoPath]
+		fileState.PreviewStatus = PreviewStatusQueued
+		state.Files[videoPath] = fileState
+	}
+
+	runningPreviews := 0
+	canStartPreview := func() bool {
+		_, ok := nextPreview(state.Files)
+		return ok && runningPreviews < maxPreviews && !state.paused()
+	}
+	startPreviews := func() error {
+		for canStartPreview() {
+			videoPath, _ := nextPreview(state.Files)
+
+			var previewUuid string
+			if err := workflow.SideEffect(ctx, newUUID).Get(&previewUuid); err != nil {
+				return fmt.Errorf("failed to generate UUID for preview activity: %w", err)
+			}
+			videoExt := filepath.Ext(videoPath)
+			var previewBase string
+			if videoExt == "" {
+				previewBase = filepath.Base(videoPath) + ".mp4"
+			} else {
+				previewBase = filepath.Base(videoPath[0:len(videoPath)-len(videoExt)]) + ".mp4"
 			}
+			generatePreviewCtx := workflow.WithActivityOptions(ctx, transcodeActivityOptions(remoteTaskQueue, state.Files[videoPath].DurationSeconds, previewTranscodeSpeed))
+			previewParams := vwactivity.TranscodeParams{
+				Uuid:               previewUuid,
+				InputPath:          videoPath,
+				OutputPath:         filepath.Join(previewDir, previewBase),
+				Profile:            "preview",
+				WebhookCompleteURI: params.WebhookBaseURI + "/transcode/complete",
+				WebhookProgressURI: params.WebhookBaseURI + "/transcode/heartbeat",
+			}
+			var transcodeDeps *vwactivity.TranscodeDeps
+			previewFuture := workflow.ExecuteActivity(generatePreviewCtx, transcodeDeps.Transcode, previewParams)
+			diagCount++
+			runningPreviews++
 			fileState := state.Files[videoPath]
-			fileState.PreviewPath = &previewParams.OutputPath
+			fileState.PreviewStatus = PreviewStatusRunning
 			state.Files[videoPath] = fileState
-		})
+			diagSelect.AddFuture(previewFuture, func(f workflow.Future) {
+				runningPreviews--
+				err := f.Get(generatePreviewCtx, nil)
+				fileState := state.Files[videoPath]
+				if err != nil {
+					logger.Error("Failed to generate preview", internal.LogKeyFilePath, videoPath, internal.LogKeyError, err)
+					errorMessage := err.Error()
+					fileState.PreviewError = &errorMessage
+					fileState.PreviewStatus = PreviewStatusFailed
+				} else {
+					fileState.PreviewPath = &previewParams.OutputPath
+					fileState.PreviewStatus = PreviewStatusDone
+				}
+				state.Files[videoPath] = fileState
+			})
+		}
+		return nil
 	}
-	for range diagCount {
-		diagSelect.Select(ctx)
+	// While paused, queued previews wait for a resume even once nothing else is running.
+	for diagCount > 0 || hasQueuedPreview(state.Files) {
+		if err := workflow.Await(ctx, func() bool { return diagSelect.HasPending() || canStartPreview() }); err != nil {
+			return state, fmt.Errorf("interrupted while getting file diagnostics: %w", err)
+		}
+		if diagSelect.HasPending() {
+			diagSelect.Select(ctx)
+			diagCount--
+		}
+		if err := startPreviews(); err != nil {
+			return state, err
+		}
 	}
-	state.GotFileDiagnostics = true
+	state.enterPhase(ctx, PhaseGotFileDiagnostics)
 
 	// TODO: Wait for the user to categorize each file and update the state.
 
 	// TODO: Move each file to its final location based on its category.
 
-	// TODO: Transcode main title.
+	// TODO: Transcode main title, with transcodeActivityOptions(remoteTaskQueue, duration, mainTitleTranscodeSpeed),
+	// and let State.CanStartAt accept StageFinalTranscode.
 
 	return state, nil
 }
 
+// saveResult records the workflow's outcome in the server's disc store, so that it can still be reported
+// once Temporal has purged the workflow's history.  A failure to record it is logged rather than changing
+// the outcome.
+func saveResult(ctx workflow.Context, logger log.Logger, params Params, state State, workflowErr error) {
+	version := workflow.GetVersion(ctx, changeSaveResult, workflow.DefaultVersion, saveResultVersion)
+	if version == workflow.DefaultVersion || params.StoreTaskQueue == "" {
+		return
+	}
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		logger.Error("Failed to encode state for the disc store", internal.LogKeyError, err)
+		return
+	}
+	saveParams := vwactivity.SaveDiscParams{
+		Uuid:   params.UUID,
+		Status: vwstore.StatusCompleted,
+		State:  encoded,
+	}
+	if workflowErr != nil {
+		errorMessage := workflowErr.Error()
+		saveParams.Status = vwstore.StatusFailed
+		saveParams.Error = &errorMessage
+	}
+	// Run even if the workflow was cancelled, and give up eventually if the server is down rather than
+	// holding the workflow open.
+	saveCtx, cancel := workflow.NewDisconnectedContext(ctx)
+	defer cancel()
+	saveCtx = workflow.WithActivityOptions(saveCtx, workflow.ActivityOptions{
+		TaskQueue:              params.StoreTaskQueue,
+		StartToCloseTimeout:    30 * time.Second,
+		ScheduleToCloseTimeout: 5 * time.Minute,
+	})
+	var storeDeps *vwactivity.StoreDeps
+	if err := workflow.ExecuteActivity(saveCtx, storeDeps.SaveDisc, saveParams).Get(saveCtx, nil); err != nil {
+		logger.Error("Failed to save disc outcome", internal.LogKeyError, err)
+	}
+}
+
+// sortedPaths returns the paths in files in a stable order.  Workflow code must use this rather than
+// ranging over the map directly whenever it schedules commands, so that replays issue the same commands
+// in the same order.
+func sortedPaths(files map[string]FileState) []string {
+	return slices.Sorted(maps.Keys(files))
+}
+
+// nextPreview picks the queued file whose preview should start next, or returns false if none is ready.
+// A file is ready once its info request has finished.  Longer titles go first since they are the most
+// likely main features; files whose duration could not be determined go last.  Ties are broken by path
+// so that the choice does not depend on map iteration order.
+func nextPreview(files map[string]FileState) (string, bool) {
+	best := ""
+	bestDuration := -1.0
+	for videoPath, fileState := range files {
+		if fileState.PreviewStatus != PreviewStatusQueued {
+			continue
+		}
+		var duration float64
+		switch {
+		case fileState.DurationSeconds != nil:
+			duration = *fileState.DurationSeconds
+		case fileState.InfoError != nil:
+			duration = 0
+		default:
+			continue
+		}
+		if duration > bestDuration || (duration == bestDuration && videoPath < best) {
+			best = videoPath
+			bestDuration = duration
+		}
+	}
+	return best, bestDuration >= 0
+}
+
+// hasQueuedPreview reports whether any file's preview has yet to start.
+func hasQueuedPreview(files map[string]FileState) bool {
+	for _, fileState := range files {
+		if fileState.PreviewStatus == PreviewStatusQueued {
+			return true
+		}
+	}
+	return false
+}
+
 func newUUID(ctx workflow.Context) any {
 	return uuid.New().String()
 }
@@ -0,0 +1,658 @@
+package vwdisc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/krelinga/video-workflows/internal/vwactivity"
+	"github.com/krelinga/video-workflows/internal/vwstore"
+)
+
+const (
+	testUUID        = "550e8400-e29b-41d4-a716-446655440000"
+	testInboxPath   = "/nas/media/inbox/disc1"
+	testLibraryPath = "/nas/media/library"
+	testPreviewPath = "/nas/media/previews"
+	testWebhookURI  = "http://server:8080/activity"
+	// testStoreTaskQueue is set as Params.StoreTaskQueue by tests that check the saved outcome.
+	testStoreTaskQueue = "store"
+
+	testFileSize = 20 << 30
+
+	// asyncDelay is how long (in workflow time) the fake services take to call back.
+	asyncDelay = 10 * time.Second
+)
+
+var (
+	testDiscPath    = filepath.Join(testLibraryPath, testUUID)
+	testPreviewDir  = filepath.Join(testPreviewPath, testUUID)
+	errFakeFailure  = errors.New("fake failure")
+	testMainTitle   = filepath.Join(testDiscPath, "title_t00.mkv")
+	testExtra       = filepath.Join(testDiscPath, "title_t01.mkv")
+	testShortExtra  = filepath.Join(testDiscPath, "title_t02.mkv")
+	testMainPreview = filepath.Join(testPreviewDir, "title_t00.mp4")
+)
+
+type asyncResult struct {
+	result any
+	err    error
+	// delay overrides asyncDelay when set.
+	delay time.Duration
+}
+
+type DiscWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+
+	// infoResults and previewResults are how the fake video-info and transcoder services complete
+	// each file's asynchronous activity.  Files without an entry are left pending.
+	infoResults    map[string]asyncResult
+	previewResults map[string]asyncResult
+
+	mu             sync.Mutex
+	previewStarts  []string
+	runningPreview int
+	maxRunning     int
+}
+
+func TestDiscWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(DiscWorkflowTestSuite))
+}
+
+func (s *DiscWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+	s.env.RegisterActivity(vwactivity.RenameFile)
+	s.env.RegisterActivity(vwactivity.ListVideoFiles)
+	s.env.RegisterActivity(vwactivity.MkDir)
+	s.env.RegisterActivity(&vwactivity.VideoInfoDeps{})
+	s.env.RegisterActivity(&vwactivity.TranscodeDeps{})
+	s.env.RegisterActivity(&vwactivity.StoreDeps{})
+
+	s.infoResults = map[string]asyncResult{}
+	s.previewResults = map[string]asyncResult{}
+	s.previewStarts = nil
+	s.runningPreview = 0
+	s.maxRunning = 0
+}
+
+func (s *DiscWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *DiscWorkflowTestSuite) params() Params {
+	return Params{
+		UUID:           testUUID,
+		Path:           testInboxPath,
+		LibraryPath:    testLibraryPath,
+		PreviewPath:    testPreviewPath,
+		WebhookBaseURI: testWebhookURI,
+	}
+}
+
+// mockFilesystem sets up successful filesystem activities that find videoPaths on the disc.
+// Every file is testFileSize bytes.
+func (s *DiscWorkflowTestSuite) mockFilesystem(videoPaths ...string) {
+	sizes := make(map[string]int64)
+	for _, videoPath := range videoPaths {
+		sizes[videoPath] = testFileSize
+	}
+	s.env.OnActivity(vwactivity.RenameFile, mock.Anything, vwactivity.RenameFileParams{
+		SourcePath: testInboxPath,
+		TargetPath: testDiscPath,
+	}).Return(nil).Once()
+	s.env.OnActivity(vwactivity.ListVideoFiles, mock.Anything, vwactivity.ListVideoFilesParams{
+		DirectoryPath: testDiscPath,
+	}).Return(&vwactivity.ListVideoFilesResult{VideoPaths: videoPaths, SizesBytes: sizes}, nil).Once()
+	s.env.OnActivity(vwactivity.MkDir, mock.Anything, vwactivity.MkDirParams{
+		Path: testPreviewDir,
+	}).Return(nil).Once()
+}
+
+// completeLater completes the calling activity after asyncDelay, the way a webhook callback would.
+func (s *DiscWorkflowTestSuite) completeLater(ctx context.Context, result asyncResult, done func()) error {
+	token := activity.GetInfo(ctx).TaskToken
+	delay := result.delay
+	if delay == 0 {
+		delay = asyncDelay
+	}
+	s.env.RegisterDelayedCallback(func() {
+		if done != nil {
+			done()
+		}
+		s.NoError(s.env.CompleteActivity(token, result.result, result.err))
+	}, delay)
+	return activity.ErrResultPending
+}
+
+// mockRemote sets up the async GetVideoInfo and Transcode activities to be completed from
+// infoResults and previewResults.
+func (s *DiscWorkflowTestSuite) mockRemote() {
+	var infoDeps *vwactivity.VideoInfoDeps
+	s.env.OnActivity(infoDeps.GetVideoInfo, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, params vwactivity.GetVideoInfoParams) (*vwactivity.VideoInfo, error) {
+			s.Equal(testWebhookURI+"/get_video_info/complete", params.WebhookCompleteURI)
+			result, ok := s.infoResults[params.VideoPath]
+			if !ok {
+				return nil, activity.ErrResultPending
+			}
+			return nil, s.completeLater(ctx, result, nil)
+		})
+
+	var transcodeDeps *vwactivity.TranscodeDeps
+	s.env.OnActivity(transcodeDeps.Transcode, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, params vwactivity.TranscodeParams) error {
+			s.Equal("preview", params.Profile)
+			s.Equal(testWebhookURI+"/transcode/complete", params.WebhookCompleteURI)
+			s.Equal(testWebhookURI+"/transcode/heartbeat", params.WebhookProgressURI)
+			s.mu.Lock()
+			s.previewStarts = append(s.previewStarts, params.InputPath)
+			s.runningPreview++
+			s.maxRunning = max(s.maxRunning, s.runningPreview)
+			s.mu.Unlock()
+			result, ok := s.previewResults[params.InputPath]
+			if !ok {
+				return activity.ErrResultPending
+			}
+			return s.completeLater(ctx, result, func() {
+				s.mu.Lock()
+				s.runningPreview--
+				s.mu.Unlock()
+			})
+		})
+}
+
+func (s *DiscWorkflowTestSuite) getResult() State {
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+	var state State
+	s.NoError(s.env.GetWorkflowResult(&state))
+	return state
+}
+
+func (s *DiscWorkflowTestSuite) queryState() State {
+	encoded, err := s.env.QueryWorkflow(QueryGetState)
+	s.Require().NoError(err)
+	var state State
+	s.Require().NoError(encoded.Get(&state))
+	return state
+}
+
+func (s *DiscWorkflowTestSuite) Test_Success() {
+	s.mockFilesystem(testMainTitle, testExtra)
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7200, ChapterDurations: []float64{3600, 3600}}}
+	s.infoResults[testExtra] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 300}}
+	s.previewResults[testMainTitle] = asyncResult{}
+	s.previewResults[testExtra] = asyncResult{}
+	s.mockRemote()
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	state := s.getResult()
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+	s.Len(state.PhaseEnteredAt, 4)
+	s.False(state.PhaseEnteredAt[PhaseFilesListed].Before(state.PhaseEnteredAt[PhaseDirectoryMoved]))
+	s.True(state.PhaseEnteredAt[PhaseGotFileDiagnostics].After(state.PhaseEnteredAt[PhaseFilesListed]),
+		"expected diagnostics to take workflow time")
+	s.Len(state.Files, 2)
+
+	s.Equal(testDiscPath, state.Directory)
+	main := state.Files[testMainTitle]
+	s.Require().NotNil(main.SizeBytes)
+	s.Equal(int64(testFileSize), *main.SizeBytes)
+	s.Require().NotNil(main.DurationSeconds)
+	s.Equal(7200.0, *main.DurationSeconds)
+	s.Equal([]float64{3600, 3600}, main.ChapterDurationsSeconds)
+	s.Require().NotNil(main.PreviewPath)
+	s.Equal(testMainPreview, *main.PreviewPath)
+	s.Equal(PreviewStatusDone, main.PreviewStatus)
+	s.Nil(main.InfoError)
+	s.Nil(main.PreviewError)
+
+	extra := state.Files[testExtra]
+	s.Require().NotNil(extra.DurationSeconds)
+	s.Equal(300.0, *extra.DurationSeconds)
+	s.NotNil(extra.PreviewPath)
+	s.Equal(PreviewStatusDone, extra.PreviewStatus)
+}
+
+func (s *DiscWorkflowTestSuite) Test_NoFiles() {
+	s.mockFilesystem()
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	state := s.getResult()
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+	s.Empty(state.Files)
+	s.Empty(s.previewStarts)
+}
+
+func (s *DiscWorkflowTestSuite) Test_RenameFails() {
+	s.env.OnActivity(vwactivity.RenameFile, mock.Anything, mock.Anything).Return(errFakeFailure)
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	s.True(s.env.IsWorkflowCompleted())
+	err := s.env.GetWorkflowError()
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to move directory")
+	s.env.AssertActivityNumberOfCalls(s.T(), "RenameFile", 3)
+	s.env.AssertActivityNotCalled(s.T(), "ListVideoFiles", mock.Anything, mock.Anything)
+}
+
+func (s *DiscWorkflowTestSuite) Test_ListFails() {
+	s.env.OnActivity(vwactivity.RenameFile, mock.Anything, mock.Anything).Return(nil)
+	s.env.OnActivity(vwactivity.ListVideoFiles, mock.Anything, mock.Anything).Return(nil, errFakeFailure)
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	s.True(s.env.IsWorkflowCompleted())
+	err := s.env.GetWorkflowError()
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to list video files")
+	// The failure carries the state reached so far.
+	state, ok := StateFromError(err)
+	s.Require().True(ok)
+	s.Equal(PhaseDirectoryMoved, state.Phase)
+	s.NotContains(state.PhaseEnteredAt, PhaseFilesListed)
+}
+
+const testSourceClaimID = "source:" + testInboxPath
+
+func (s *DiscWorkflowTestSuite) Test_ReleasesSourceClaimOnceMoved() {
+	released := false
+	s.env.OnSignalExternalWorkflow(mock.Anything, testSourceClaimID, "", SignalReleaseSource, nil).
+		Run(func(args mock.Arguments) { released = true }).Return(nil).Once()
+	s.env.OnActivity(vwactivity.RenameFile, mock.Anything, mock.Anything).Return(nil).Once()
+	s.env.OnActivity(vwactivity.ListVideoFiles, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, params vwactivity.ListVideoFilesParams) (*vwactivity.ListVideoFilesResult, error) {
+			s.True(released, "expected the claim to be released before listing files")
+			return &vwactivity.ListVideoFilesResult{}, nil
+		}).Once()
+	s.env.OnActivity(vwactivity.MkDir, mock.Anything, mock.Anything).Return(nil).Once()
+
+	params := s.params()
+	params.SourceClaimID = SourceClaimID(testInboxPath + "/")
+	s.env.ExecuteWorkflow(Workflow, params)
+
+	s.getResult()
+	s.True(released)
+}
+
+func (s *DiscWorkflowTestSuite) Test_ReleasesSourceClaimWhenMoveFails() {
+	s.env.OnSignalExternalWorkflow(mock.Anything, testSourceClaimID, "", SignalReleaseSource, nil).Return(nil).Once()
+	s.env.OnActivity(vwactivity.RenameFile, mock.Anything, mock.Anything).Return(errFakeFailure)
+
+	params := s.params()
+	params.SourceClaimID = testSourceClaimID
+	s.env.ExecuteWorkflow(Workflow, params)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+}
+
+func TestClaimSource(t *testing.T) {
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.RegisterDelayedCallback(func() {
+		if env.IsWorkflowCompleted() {
+			t.Error("expected the claim to be held until released")
+		}
+		env.SignalWorkflow(SignalReleaseSource, nil)
+	}, time.Hour)
+
+	env.ExecuteWorkflow(ClaimSource)
+
+	if !env.IsWorkflowCompleted() {
+		t.Fatal("expected the claim to end once released")
+	}
+	if err := env.GetWorkflowError(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// mockStore captures the outcome the workflow saves to the disc store.
+func (s *DiscWorkflowTestSuite) mockStore(saved *vwactivity.SaveDiscParams) {
+	var storeDeps *vwactivity.StoreDeps
+	s.env.OnActivity(storeDeps.SaveDisc, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, params vwactivity.SaveDiscParams) error {
+			s.Equal(testStoreTaskQueue, activity.GetInfo(ctx).TaskQueue)
+			*saved = params
+			return nil
+		}).Once()
+}
+
+func (s *DiscWorkflowTestSuite) Test_SavesResult() {
+	s.mockFilesystem()
+	var saved vwactivity.SaveDiscParams
+	s.mockStore(&saved)
+	params := s.params()
+	params.StoreTaskQueue = testStoreTaskQueue
+
+	s.env.ExecuteWorkflow(Workflow, params)
+
+	s.getResult()
+	s.Equal(testUUID, saved.Uuid)
+	s.Equal(vwstore.StatusCompleted, saved.Status)
+	s.Nil(saved.Error)
+	var state State
+	s.Require().NoError(json.Unmarshal(saved.State, &state))
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+}
+
+func (s *DiscWorkflowTestSuite) Test_SavesFailure() {
+	s.env.OnActivity(vwactivity.RenameFile, mock.Anything, mock.Anything).Return(errFakeFailure)
+	var saved vwactivity.SaveDiscParams
+	s.mockStore(&saved)
+	params := s.params()
+	params.StoreTaskQueue = testStoreTaskQueue
+
+	s.env.ExecuteWorkflow(Workflow, params)
+
+	s.Require().Error(s.env.GetWorkflowError())
+	s.Equal(vwstore.StatusFailed, saved.Status)
+	s.Require().NotNil(saved.Error)
+	s.Contains(*saved.Error, "failed to move directory")
+}
+
+func (s *DiscWorkflowTestSuite) Test_InfoFailure() {
+	s.mockFilesystem(testMainTitle, testExtra)
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7200}}
+	s.infoResults[testExtra] = asyncResult{err: errFakeFailure}
+	s.previewResults[testMainTitle] = asyncResult{}
+	s.previewResults[testExtra] = asyncResult{}
+	s.mockRemote()
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	state := s.getResult()
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+	extra := state.Files[testExtra]
+	s.Nil(extra.DurationSeconds)
+	s.Require().NotNil(extra.InfoError)
+	s.Contains(*extra.InfoError, errFakeFailure.Error())
+	// A file without info still gets a preview, just after everything else.
+	s.Equal(PreviewStatusDone, extra.PreviewStatus)
+	s.NotNil(state.Files[testMainTitle].DurationSeconds)
+}
+
+func (s *DiscWorkflowTestSuite) Test_InfoRejected() {
+	s.mockFilesystem(testMainTitle)
+	var infoDeps *vwactivity.VideoInfoDeps
+	s.env.OnActivity(infoDeps.GetVideoInfo, mock.Anything, mock.Anything).Return(
+		nil, temporal.NewNonRetryableApplicationError("bad path", vwactivity.ErrorTypeBadRequest, nil))
+	var transcodeDeps *vwactivity.TranscodeDeps
+	s.env.OnActivity(transcodeDeps.Transcode, mock.Anything, mock.Anything).Return(nil)
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	state := s.getResult()
+	s.Require().NotNil(state.Files[testMainTitle].InfoError)
+	s.env.AssertActivityNumberOfCalls(s.T(), "GetVideoInfo", 1)
+}
+
+func (s *DiscWorkflowTestSuite) Test_InfoServerErrorRetried() {
+	s.mockFilesystem(testMainTitle)
+	var infoDeps *vwactivity.VideoInfoDeps
+	s.env.OnActivity(infoDeps.GetVideoInfo, mock.Anything, mock.Anything).Return(nil, errFakeFailure)
+	var transcodeDeps *vwactivity.TranscodeDeps
+	s.env.OnActivity(transcodeDeps.Transcode, mock.Anything, mock.Anything).Return(nil)
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	state := s.getResult()
+	s.Require().NotNil(state.Files[testMainTitle].InfoError)
+	s.env.AssertActivityNumberOfCalls(s.T(), "GetVideoInfo", int(remoteRetryPolicy.MaximumAttempts))
+}
+
+func (s *DiscWorkflowTestSuite) Test_PreviewFailure() {
+	s.mockFilesystem(testMainTitle, testExtra)
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7200}}
+	s.infoResults[testExtra] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 300}}
+	s.previewResults[testMainTitle] = asyncResult{}
+	s.previewResults[testExtra] = asyncResult{err: errFakeFailure}
+	s.mockRemote()
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	state := s.getResult()
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+	extra := state.Files[testExtra]
+	s.Nil(extra.PreviewPath)
+	s.Equal(PreviewStatusFailed, extra.PreviewStatus)
+	s.Require().NotNil(extra.PreviewError)
+	s.Contains(*extra.PreviewError, errFakeFailure.Error())
+	s.Equal(PreviewStatusDone, state.Files[testMainTitle].PreviewStatus)
+}
+
+func (s *DiscWorkflowTestSuite) Test_QueryWhilePending() {
+	s.mockFilesystem(testMainTitle, testExtra)
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7200}}
+	// testExtra's info never arrives, and no preview ever completes.
+	s.mockRemote()
+
+	var beforeInfo, afterInfo State
+	s.env.RegisterDelayedCallback(func() {
+		beforeInfo = s.queryState()
+	}, asyncDelay/2)
+	s.env.RegisterDelayedCallback(func() {
+		afterInfo = s.queryState()
+		s.env.CancelWorkflow()
+	}, asyncDelay*2)
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	s.Equal(PhaseFilesListed, beforeInfo.Phase)
+	s.Equal(PreviewStatusQueued, beforeInfo.Files[testMainTitle].PreviewStatus)
+	s.Equal(PreviewStatusQueued, beforeInfo.Files[testExtra].PreviewStatus)
+
+	s.Equal(PhaseFilesListed, afterInfo.Phase)
+	s.NotNil(afterInfo.Files[testMainTitle].DurationSeconds)
+	s.Equal(PreviewStatusRunning, afterInfo.Files[testMainTitle].PreviewStatus)
+	s.Equal(PreviewStatusQueued, afterInfo.Files[testExtra].PreviewStatus)
+}
+
+func (s *DiscWorkflowTestSuite) Test_PauseAndResume() {
+	// Pausing while the info requests are out lets them finish, but holds back the previews.
+	s.mockFilesystem(testMainTitle, testExtra)
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7200}}
+	s.infoResults[testExtra] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 300}}
+	s.previewResults[testMainTitle] = asyncResult{}
+	s.previewResults[testExtra] = asyncResult{}
+	s.mockRemote()
+
+	var paused State
+	var previewsWhilePaused int
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(SignalPause, nil)
+	}, asyncDelay/2)
+	s.env.RegisterDelayedCallback(func() {
+		paused = s.queryState()
+		previewsWhilePaused = len(s.previewStarts)
+		s.env.SignalWorkflow(SignalResume, nil)
+	}, asyncDelay*3)
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	s.Equal(PhasePaused, paused.Phase)
+	s.Equal(PhaseFilesListed, paused.PausedFrom)
+	s.NotNil(paused.Files[testMainTitle].DurationSeconds)
+	s.NotNil(paused.Files[testExtra].DurationSeconds)
+	s.Equal(PreviewStatusQueued, paused.Files[testMainTitle].PreviewStatus)
+	s.Equal(PreviewStatusQueued, paused.Files[testExtra].PreviewStatus)
+	s.Zero(previewsWhilePaused)
+
+	state := s.getResult()
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+	s.Empty(state.PausedFrom)
+	s.Contains(state.PhaseEnteredAt, PhasePaused)
+	s.Len(s.previewStarts, 2)
+}
+
+func (s *DiscWorkflowTestSuite) Test_PausedWhenDone() {
+	// Once every activity has started there is nothing left to hold back, so the workflow finishes and
+	// reports the phase it reached rather than staying paused.
+	s.mockFilesystem(testMainTitle)
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7200}}
+	s.previewResults[testMainTitle] = asyncResult{}
+	s.mockRemote()
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(SignalPause, nil)
+	}, asyncDelay*3/2)
+
+	s.env.ExecuteWorkflow(Workflow, s.params())
+
+	state := s.getResult()
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+	s.Empty(state.PausedFrom)
+	s.Equal(PreviewStatusDone, state.Files[testMainTitle].PreviewStatus)
+}
+
+// previousState is the state of an earlier run that got diagnostics for testMainTitle.
+func previousState() State {
+	duration := 7200.0
+	size := int64(testFileSize)
+	category := FileCategoryMainTitle
+	preview := testMainPreview
+	return State{
+		Phase:     PhaseGotFileDiagnostics,
+		Directory: testDiscPath,
+		PhaseEnteredAt: map[Phase]time.Time{
+			PhaseRunning:            time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC),
+			PhaseDirectoryMoved:     time.Date(2026, 1, 2, 15, 0, 1, 0, time.UTC),
+			PhaseFilesListed:        time.Date(2026, 1, 2, 15, 0, 2, 0, time.UTC),
+			PhaseGotFileDiagnostics: time.Date(2026, 1, 2, 16, 0, 0, 0, time.UTC),
+		},
+		Files: map[string]FileState{testMainTitle: {
+			SizeBytes:       &size,
+			DurationSeconds: &duration,
+			Category:        &category,
+			PreviewPath:     &preview,
+			PreviewStatus:   PreviewStatusDone,
+		}},
+	}
+}
+
+func (s *DiscWorkflowTestSuite) Test_ReprocessFromListFiles() {
+	s.env.OnActivity(vwactivity.ListVideoFiles, mock.Anything, vwactivity.ListVideoFilesParams{
+		DirectoryPath: testDiscPath,
+	}).Return(&vwactivity.ListVideoFilesResult{VideoPaths: []string{testMainTitle, testExtra}}, nil).Once()
+	s.env.OnActivity(vwactivity.MkDir, mock.Anything, vwactivity.MkDirParams{Path: testPreviewDir}).Return(nil).Once()
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7200}}
+	s.infoResults[testExtra] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 300}}
+	s.previewResults[testMainTitle] = asyncResult{}
+	s.previewResults[testExtra] = asyncResult{}
+	s.mockRemote()
+
+	params := s.params()
+	params.Path = ""
+	params.StartStage = StageListFiles
+	previous := previousState()
+	params.PreviousState = &previous
+	s.env.ExecuteWorkflow(Workflow, params)
+
+	state := s.getResult()
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+	s.Equal(previous.PhaseEnteredAt[PhaseDirectoryMoved], state.PhaseEnteredAt[PhaseDirectoryMoved])
+	s.NotEqual(previous.PhaseEnteredAt[PhaseFilesListed], state.PhaseEnteredAt[PhaseFilesListed])
+	s.Len(state.Files, 2)
+	s.Nil(state.Files[testMainTitle].Category)
+	s.ElementsMatch([]string{testMainTitle, testExtra}, s.previewStarts)
+}
+
+func (s *DiscWorkflowTestSuite) Test_ReprocessFromDiagnostics() {
+	s.env.OnActivity(vwactivity.MkDir, mock.Anything, vwactivity.MkDirParams{Path: testPreviewDir}).Return(nil).Once()
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7100}}
+	s.previewResults[testMainTitle] = asyncResult{}
+	s.mockRemote()
+
+	params := s.params()
+	params.Path = ""
+	params.StartStage = StageDiagnostics
+	previous := previousState()
+	params.PreviousState = &previous
+	s.env.ExecuteWorkflow(Workflow, params)
+
+	state := s.getResult()
+	s.Equal(PhaseGotFileDiagnostics, state.Phase)
+	s.Equal(previous.PhaseEnteredAt[PhaseFilesListed], state.PhaseEnteredAt[PhaseFilesListed])
+	s.NotEqual(previous.PhaseEnteredAt[PhaseGotFileDiagnostics], state.PhaseEnteredAt[PhaseGotFileDiagnostics])
+	main := state.Files[testMainTitle]
+	s.Equal(previous.Files[testMainTitle].SizeBytes, main.SizeBytes)
+	s.Equal(previous.Files[testMainTitle].Category, main.Category)
+	s.Require().NotNil(main.DurationSeconds)
+	s.Equal(7100.0, *main.DurationSeconds)
+	s.Equal(PreviewStatusDone, main.PreviewStatus)
+	s.Equal([]string{testMainTitle}, s.previewStarts)
+}
+
+func (s *DiscWorkflowTestSuite) Test_ReprocessBeforeStageReached() {
+	params := s.params()
+	params.StartStage = StageDiagnostics
+	previous := State{
+		Phase:          PhaseDirectoryMoved,
+		Directory:      testDiscPath,
+		PhaseEnteredAt: map[Phase]time.Time{PhaseDirectoryMoved: time.Now()},
+	}
+	params.PreviousState = &previous
+	s.env.ExecuteWorkflow(Workflow, params)
+
+	s.True(s.env.IsWorkflowCompleted())
+	err := s.env.GetWorkflowError()
+	s.Require().Error(err)
+	s.Contains(err.Error(), "cannot reprocess disc")
+}
+
+func (s *DiscWorkflowTestSuite) Test_PreviewWindow() {
+	// The short extra's info arrives first, so its preview takes the only slot.  By the time that preview
+	// finishes both other files are queued, and the longer one must go next.
+	s.mockFilesystem(testShortExtra, testMainTitle, testExtra)
+	s.infoResults[testShortExtra] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 30}, delay: 10 * time.Second}
+	s.infoResults[testExtra] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 300}, delay: 20 * time.Second}
+	s.infoResults[testMainTitle] = asyncResult{result: vwactivity.VideoInfo{DurationSeconds: 7200}, delay: 30 * time.Second}
+	s.previewResults[testMainTitle] = asyncResult{delay: time.Minute}
+	s.previewResults[testExtra] = asyncResult{delay: time.Minute}
+	s.previewResults[testShortExtra] = asyncResult{delay: time.Minute}
+	s.mockRemote()
+
+	params := s.params()
+	params.MaxConcurrentPreviews = 1
+	s.env.ExecuteWorkflow(Workflow, params)
+
+	state := s.getResult()
+	for _, fileState := range state.Files {
+		s.Equal(PreviewStatusDone, fileState.PreviewStatus)
+	}
+	s.Equal(1, s.maxRunning)
+	s.Equal([]string{testShortExtra, testMainTitle, testExtra}, s.previewStarts)
+}
+
+func (s *DiscWorkflowTestSuite) Test_TranscodeActivityOptions() {
+	duration := 3600.0
+	options := transcodeActivityOptions("queue", &duration, previewTranscodeSpeed)
+	s.Equal("queue", options.TaskQueue)
+	s.Equal(minTranscodeTimeout+time.Hour, options.StartToCloseTimeout)
+	s.Equal(transcodeHeartbeatTimeout, options.HeartbeatTimeout)
+	s.Equal(transcodeRetryPolicy, options.RetryPolicy)
+
+	// Slower profiles get proportionally longer.
+	s.Equal(minTranscodeTimeout+4*time.Hour, transcodeActivityOptions("queue", &duration, mainTitleTranscodeSpeed).StartToCloseTimeout)
+
+	// Unknown and absurd durations are capped.
+	s.Equal(maxTranscodeTimeout, transcodeActivityOptions("queue", nil, previewTranscodeSpeed).StartToCloseTimeout)
+	huge := 1e9
+	s.Equal(maxTranscodeTimeout, transcodeActivityOptions("queue", &huge, previewTranscodeSpeed).StartToCloseTimeout)
+}
@@ -0,0 +1,241 @@
+package vwdisc
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/temporalproto"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/vwactivity"
+)
+
+// Golden histories live in testdata/histories and are replayed against the current Workflow code by
+// TestReplay.  A replay failure means the change would break discs that are already in flight; see
+// versions.go before changing the commands it issues.
+//
+// To record a new set of histories against a local dev server, run:
+//
+//	go test ./internal/workflows/vwdisc -run TestRecordHistories -record-histories
+//
+// Recording needs the Temporal CLI.  It is downloaded automatically unless -temporal-cli names an
+// existing binary.  Existing histories must be kept, not re-recorded, since they stand in for discs
+// started by older workers; bump historyGeneration to record a new set alongside them.
+var (
+	recordHistories = flag.Bool("record-histories", false, "record golden workflow histories into testdata")
+	temporalCLI     = flag.String("temporal-cli", "", "path to an existing Temporal CLI binary used by -record-histories")
+)
+
+const historiesDir = "testdata/histories"
+
+// historyGeneration prefixes the names of newly recorded histories.  Bump it whenever a version in
+// versions.go changes, so that the new branch is recorded alongside the histories of the old one.
+//
+//	0: before change IDs were introduced.
+//	1: every stage at version 1.
+//	2: results saved to the disc store.
+//	3: the inbox directory's claim released once moved.
+const historyGeneration = 3
+
+func TestReplay(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join(historiesDir, "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list histories: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("no histories found in %s", historiesDir)
+	}
+
+	replayer := worker.NewWorkflowReplayer()
+	replayer.RegisterWorkflow(Workflow)
+	for _, path := range paths {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+			if err := replayer.ReplayWorkflowHistoryFromJSONFile(nil, path); err != nil {
+				t.Errorf("failed to replay %s: %v", path, err)
+			}
+		})
+	}
+}
+
+// recording describes one workflow execution captured by TestRecordHistories.  The fake activities
+// complete synchronously, since the replayer only sees the resulting events and not how the activity
+// was completed.  Their failures are non-retryable so that the execution finishes without waiting out
+// the default retry policy.
+type recording struct {
+	name         string
+	files        []string
+	infoErrors   map[string]bool
+	previewFails map[string]bool
+	maxPreviews  int
+}
+
+var recordings = []recording{
+	{
+		name:  "success",
+		files: []string{"title_t00.mkv", "title_t01.mkv", "title_t02.mkv"},
+	},
+	{
+		name:         "partial_failures",
+		files:        []string{"title_t00.mkv", "title_t01.mkv", "title_t02.mkv"},
+		infoErrors:   map[string]bool{"title_t01.mkv": true},
+		previewFails: map[string]bool{"title_t02.mkv": true},
+		maxPreviews:  1,
+	},
+	{
+		name: "no_files",
+	},
+}
+
+// recordingDurations gives the fake video info for each file, chosen so that the preview window has
+// to reorder them.
+var recordingDurations = map[string]float64{
+	"title_t00.mkv": 300,
+	"title_t01.mkv": 7200,
+	"title_t02.mkv": 30,
+}
+
+func TestRecordHistories(t *testing.T) {
+	if !*recordHistories {
+		t.Skip("pass -record-histories to record golden histories")
+	}
+	ctx := context.Background()
+	server, err := testsuite.StartDevServer(ctx, testsuite.DevServerOptions{
+		ExistingPath: *temporalCLI,
+		ClientOptions: &client.Options{
+			Namespace: "default",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to start dev server: %v", err)
+	}
+	defer server.Stop()
+	c := server.Client()
+
+	for _, rec := range recordings {
+		t.Run(rec.name, func(t *testing.T) {
+			path := filepath.Join(historiesDir, fmt.Sprintf("v%d_%s.json", historyGeneration, rec.name))
+			if _, err := os.Stat(path); err == nil {
+				t.Skipf("%s already exists", path)
+			}
+			recordHistory(t, ctx, c, rec, path)
+		})
+	}
+}
+
+func recordHistory(t *testing.T, ctx context.Context, c client.Client, rec recording, path string) {
+	workflowWorker := worker.New(c, internal.TaskQueue, worker.Options{})
+	workflowWorker.RegisterWorkflow(Workflow)
+	workflowWorker.RegisterWorkflow(ClaimSource)
+
+	filesystemWorker := worker.New(c, internal.DefaultFilesystemTaskQueue, worker.Options{})
+	filesystemWorker.RegisterActivityWithOptions(func(ctx context.Context, params vwactivity.RenameFileParams) error {
+		return nil
+	}, activity.RegisterOptions{Name: "RenameFile"})
+	filesystemWorker.RegisterActivityWithOptions(func(ctx context.Context, params vwactivity.ListVideoFilesParams) (*vwactivity.ListVideoFilesResult, error) {
+		result := &vwactivity.ListVideoFilesResult{}
+		for _, file := range rec.files {
+			result.VideoPaths = append(result.VideoPaths, filepath.Join(params.DirectoryPath, file))
+		}
+		return result, nil
+	}, activity.RegisterOptions{Name: "ListVideoFiles"})
+	filesystemWorker.RegisterActivityWithOptions(func(ctx context.Context, params vwactivity.MkDirParams) error {
+		return nil
+	}, activity.RegisterOptions{Name: "MkDir"})
+
+	remoteWorker := worker.New(c, internal.DefaultRemoteTaskQueue, worker.Options{})
+	remoteWorker.RegisterActivityWithOptions(func(ctx context.Context, params vwactivity.GetVideoInfoParams) (*vwactivity.VideoInfo, error) {
+		file := filepath.Base(params.VideoPath)
+		if rec.infoErrors[file] {
+			return nil, temporal.NewNonRetryableApplicationError("fake info failure", "FakeFailure", nil)
+		}
+		return &vwactivity.VideoInfo{DurationSeconds: recordingDurations[file]}, nil
+	}, activity.RegisterOptions{Name: "GetVideoInfo"})
+	remoteWorker.RegisterActivityWithOptions(func(ctx context.Context, params vwactivity.TranscodeParams) error {
+		// Give the window a chance to fill so that the history shows previews overlapping.
+		time.Sleep(100 * time.Millisecond)
+		if rec.previewFails[filepath.Base(params.InputPath)] {
+			return temporal.NewNonRetryableApplicationError("fake preview failure", "FakeFailure", nil)
+		}
+		return nil
+	}, activity.RegisterOptions{Name: "Transcode"})
+
+	storeWorker := worker.New(c, testStoreTaskQueue, worker.Options{})
+	storeWorker.RegisterActivityWithOptions(func(ctx context.Context, params vwactivity.SaveDiscParams) error {
+		return nil
+	}, activity.RegisterOptions{Name: "SaveDisc"})
+
+	for _, w := range []worker.Worker{workflowWorker, filesystemWorker, remoteWorker, storeWorker} {
+		if err := w.Start(); err != nil {
+			t.Fatalf("failed to start worker: %v", err)
+		}
+		defer w.Stop()
+	}
+
+	workflowID := fmt.Sprintf("record-v%d-%s", historyGeneration, rec.name)
+	claimID := SourceClaimID(testInboxPath) + "-" + workflowID
+	claimRun, err := c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        claimID,
+		TaskQueue: internal.TaskQueue,
+	}, ClaimSource)
+	if err != nil {
+		t.Fatalf("failed to claim source: %v", err)
+	}
+	run, err := c.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:        workflowID,
+		TaskQueue: internal.TaskQueue,
+	}, Workflow, Params{
+		UUID:                  testUUID,
+		Path:                  testInboxPath,
+		LibraryPath:           testLibraryPath,
+		PreviewPath:           testPreviewPath,
+		WebhookBaseURI:        testWebhookURI,
+		MaxConcurrentPreviews: rec.maxPreviews,
+		StoreTaskQueue:        testStoreTaskQueue,
+		SourceClaimID:         claimID,
+	})
+	if err != nil {
+		t.Fatalf("failed to start workflow: %v", err)
+	}
+	var state State
+	if err := run.Get(ctx, &state); err != nil {
+		t.Fatalf("workflow failed: %v", err)
+	}
+	if err := claimRun.Get(ctx, nil); err != nil {
+		t.Fatalf("source claim failed: %v", err)
+	}
+	writeHistory(t, ctx, c, workflowID, path)
+}
+
+func writeHistory(t *testing.T, ctx context.Context, c client.Client, workflowID, path string) {
+	hist := &history.History{}
+	iter := c.GetWorkflowHistory(ctx, workflowID, "", false, 0)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			t.Fatalf("failed to read history: %v", err)
+		}
+		hist.Events = append(hist.Events, event)
+	}
+	data, err := temporalproto.CustomJSONMarshalOptions{Indent: "  "}.Marshal(hist)
+	if err != nil {
+		t.Fatalf("failed to marshal history: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,67 @@
+package vwdisc
+
+import "go.temporal.io/sdk/workflow"
+
+// Discs can sit waiting for review for days, so Workflow must keep replaying the histories of
+// executions started by older workers.  Each stage of Workflow is guarded by its own change ID, and any
+// change to the commands a stage issues (adding, removing or reordering activities, timers or side
+// effects, or changing which activity is called) must be made behind a new version of that stage's
+// change ID:
+//
+//	version := workflow.GetVersion(ctx, changeListFiles, workflow.DefaultVersion, listFilesVersion)
+//	if version < 2 { ...old commands... } else { ...new commands... }
+//
+// Bump the matching *Version constant, document the new branch below, and record new golden histories
+// (see replay_test.go) so that both the old and the new branch stay covered.  A branch may only be
+// deleted once no execution that took it can still be running.
+//
+// While every version of a stage issues the same commands, Workflow records the marker without
+// branching on it.  Changes that do not affect commands, such as how results are stored in State or
+// activity timeouts, do not need a new version.  Nor does waiting before a command, as Workflow does
+// while paused: histories without the signals that cause the wait replay unchanged.  Likewise, stages
+// may be skipped on Params that no existing history carries, such as StartStage.
+const (
+	// changeMoveDirectory guards moving the disc from the inbox into the library.
+	//
+	//	DefaultVersion: started before change IDs were introduced; same as 1.
+	//	1: RenameFile on the filesystem queue.
+	changeMoveDirectory                   = "move-directory"
+	moveDirectoryVersion workflow.Version = 1
+
+	// changeListFiles guards listing the video files on the disc.
+	//
+	//	DefaultVersion: started before change IDs were introduced; same as 1.
+	//	1: ListVideoFiles on the filesystem queue.
+	changeListFiles                   = "list-files"
+	listFilesVersion workflow.Version = 1
+
+	// changePreviewDir guards creating the disc's preview directory.
+	//
+	//	DefaultVersion: started before change IDs were introduced; same as 1.
+	//	1: MkDir on the filesystem queue.
+	changePreviewDir                   = "preview-dir"
+	previewDirVersion workflow.Version = 1
+
+	// changeDiagnostics guards fetching video info and generating previews for each file.
+	//
+	//	DefaultVersion: started before change IDs were introduced; same as 1.
+	//	1: GetVideoInfo for every file in path order, then Transcode through a window of
+	//	   MaxConcurrentPreviews, longest titles first.
+	changeDiagnostics                   = "diagnostics"
+	diagnosticsVersion workflow.Version = 1
+
+	// changeSaveResult guards recording the disc's outcome once the workflow ends.
+	//
+	//	DefaultVersion: started before results were recorded; records nothing.
+	//	1: SaveDisc on the store queue, if Params.StoreTaskQueue is set.
+	changeSaveResult                   = "save-result"
+	saveResultVersion workflow.Version = 1
+
+	// changeReleaseSource guards releasing the claim on the disc's inbox directory.
+	//
+	//	DefaultVersion: started before directories were claimed; releases nothing.
+	//	1: SignalExternalWorkflow to Params.SourceClaimID, if set, once the directory has been moved or
+	//	   the workflow ends without moving it.
+	changeReleaseSource                   = "release-source"
+	releaseSourceVersion workflow.Version = 1
+)
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/vwactivity"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/api/serviceerror"
+)
+
+// Kinds of completion callback, used to keep the UUIDs of different services' jobs apart in callbackLog.
+const (
+	callbackGetVideoInfo = "get_video_info"
+	callbackTranscode    = "transcode"
+)
+
+// callbackLogSize bounds how many completion callbacks are remembered.  It only needs to cover the
+// window in which a service might retry a callback.
+const callbackLogSize = 10000
+
+// callbackLog remembers the UUIDs of recently processed completion callbacks, so that a service retrying
+// a callback that was already handled gets the same answer rather than an error from Temporal.  It is
+// held in memory, so a duplicate that arrives after a restart or after its entry has been evicted is not
+// recognized; Temporal then reports the activity as gone and the caller gets a 410 instead.
+type callbackLog struct {
+	mu    sync.Mutex
+	seen  map[string]struct{}
+	order []string
+	next  int
+}
+
+func newCallbackLog(size int) *callbackLog {
+	return &callbackLog{
+		seen:  make(map[string]struct{}, size),
+		order: make([]string, 0, size),
+	}
+}
+
+func callbackKey(kind string, id openapi_types.UUID) string {
+	return kind + "/" + id.String()
+}
+
+// Contains reports whether a callback of the given kind and UUID has been recorded.  Callbacks without a
+// UUID are never considered duplicates.
+func (l *callbackLog) Contains(kind string, id *openapi_types.UUID) bool {
+	if id == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.seen[callbackKey(kind, *id)]
+	return ok
+}
+
+// Record remembers a processed callback, evicting the oldest entry once the log is full.
+func (l *callbackLog) Record(kind string, id *openapi_types.UUID) {
+	if id == nil {
+		return
+	}
+	key := callbackKey(kind, *id)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.seen[key]; ok {
+		return
+	}
+	if len(l.order) < cap(l.order) {
+		l.order = append(l.order, key)
+	} else {
+		delete(l.seen, l.order[l.next])
+		l.order[l.next] = key
+		l.next = (l.next + 1) % len(l.order)
+	}
+	l.seen[key] = struct{}{}
+}
+
+// isActivityGone reports whether err from completing or heartbeating an activity means that the activity
+// has already completed, timed out, or belongs to a workflow that no longer exists.  Retrying such a
+// callback can never succeed.
+func isActivityGone(err error) bool {
+	var notFound *serviceerror.NotFound
+	return errors.As(err, &notFound)
+}
+
+var (
+	errCallbackMismatch  = errors.New("callback UUID does not match the job the token was issued for")
+	errBareCallbackToken = errors.New("callback token does not name its job")
+)
+
+// parseCallbackToken checks that a callback's UUID is the job its token was issued for, and returns the
+// decoded token.  Bare task tokens, sent for jobs started before tokens carried the job UUID, are
+// rejected unless the server is configured to accept them, in which case they pass through unchecked.
+func (s *Server) parseCallbackToken(ctx context.Context, token []byte, jobUUID *openapi_types.UUID) (vwactivity.CallbackToken, error) {
+	callbackToken, ok := vwactivity.ParseCallbackToken(token)
+	if !ok {
+		if !s.config.AllowBareCallbackTokens {
+			return vwactivity.CallbackToken{}, errBareCallbackToken
+		}
+		slog.WarnContext(ctx, "Callback carries a bare task token, not checking its UUID")
+		return vwactivity.CallbackToken{TaskToken: token}, nil
+	}
+	tokenUUID, err := uuid.Parse(callbackToken.JobUUID)
+	if err != nil {
+		return vwactivity.CallbackToken{}, fmt.Errorf("%w: token has invalid job UUID %q", errCallbackMismatch, callbackToken.JobUUID)
+	}
+	if jobUUID == nil || *jobUUID != tokenUUID {
+		return vwactivity.CallbackToken{}, fmt.Errorf("%w: token is for job %s", errCallbackMismatch, tokenUUID)
+	}
+	return callbackToken, nil
+}
+
+// startCallbackSpan starts a span for passing a callback on to the activity identified by token.  The span
+// continues the activity's trace, so that the callback shows up in the disc's trace rather than starting
+// one of its own, and links to the span of the request that carried the callback.
+func startCallbackSpan(ctx context.Context, name string, token vwactivity.CallbackToken) (context.Context, trace.Span) {
+	var options []trace.SpanStartOption
+	if requestSpan := trace.SpanContextFromContext(ctx); requestSpan.IsValid() && len(token.TraceContext) > 0 {
+		options = append(options, trace.WithLinks(trace.Link{SpanContext: requestSpan}))
+	}
+	ctx = internal.ExtractTraceContext(ctx, token.TraceContext)
+	return otel.Tracer(internal.TracerName).Start(ctx, name, options...)
+}
+
+// callbackLogContext adds the disc that token's activity belongs to to ctx's log attributes, if the token
+// names it.  Activities run in the disc workflow, whose ID is the disc's UUID, so tokens issued before
+// they carried DiscUUID still name it in ID mode.
+func callbackLogContext(ctx context.Context, token vwactivity.CallbackToken) context.Context {
+	discUUID := token.DiscUUID
+	if discUUID == "" {
+		discUUID = token.WorkflowID
+	}
+	if discUUID == "" {
+		return ctx
+	}
+	return internal.WithLogAttrs(ctx, internal.LogKeyDiscUUID, discUUID, internal.LogKeyWorkflowID, discUUID)
+}
+
+// completeActivity completes the activity identified by token, by ID or by task token as the token
+// says.
+func (s *Server) completeActivity(ctx context.Context, token vwactivity.CallbackToken, result any, activityErr error) error {
+	ctx, span := startCallbackSpan(ctx, "CompleteActivity", token)
+	defer span.End()
+	if token.ByID() {
+		return s.temporalClient.CompleteActivityByID(ctx, token.Namespace, token.WorkflowID, token.RunID, token.ActivityID, result, activityErr)
+	}
+	return s.temporalClient.CompleteActivity(ctx, token.TaskToken, result, activityErr)
+}
+
+// recordHeartbeat records a heartbeat for the activity identified by token.
+func (s *Server) recordHeartbeat(ctx context.Context, token vwactivity.CallbackToken, details any) error {
+	ctx, span := startCallbackSpan(ctx, "RecordActivityHeartbeat", token)
+	defer span.End()
+	if token.ByID() {
+		return s.temporalClient.RecordActivityHeartbeatByID(ctx, token.Namespace, token.WorkflowID, token.RunID, token.ActivityID, details)
+	}
+	return s.temporalClient.RecordActivityHeartbeat(ctx, token.TaskToken, details)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+// claimStartGrace is how long a source claim may go without its disc existing before the claim is
+// treated as stale.  The server starts the disc right after claiming its source, so only a server that
+// died in between leaves a claim like that behind.
+const claimStartGrace = time.Minute
+
+// sourceClaimedError reports that another disc is already ingesting a source directory.
+type sourceClaimedError struct {
+	discUUID string
+}
+
+func (e *sourceClaimedError) Error() string {
+	return fmt.Sprintf("source is already being ingested by disc %s", e.discUUID)
+}
+
+// claimSource claims the inbox directory with the given claim ID for the disc discUUID, so that no other
+// disc can be created from it until the disc has moved it into the library.  It returns a
+// *sourceClaimedError if another disc holds the claim.  Claims held by discs that are no longer running
+// are stale, and are replaced.  created is false if discUUID already held the claim.
+func (s *Server) claimSource(ctx context.Context, claimID, discUUID string) (created bool, err error) {
+	options := client.StartWorkflowOptions{
+		ID:                       claimID,
+		TaskQueue:                internal.TaskQueue,
+		WorkflowIDConflictPolicy: enums.WORKFLOW_ID_CONFLICT_POLICY_FAIL,
+		WorkflowIDReusePolicy:    enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		Memo:                     map[string]any{vwdisc.MemoClaimDiscUUID: discUUID},
+	}
+	for range 2 {
+		_, err := s.temporalClient.ExecuteWorkflow(ctx, options, vwdisc.ClaimSource)
+		var alreadyStartedErr *serviceerror.WorkflowExecutionAlreadyStarted
+		if err == nil {
+			return true, nil
+		} else if !errors.As(err, &alreadyStartedErr) {
+			return false, fmt.Errorf("failed to claim source: %w", err)
+		}
+
+		owner, claimedAt, err := s.claimOwner(ctx, claimID)
+		if err != nil {
+			return false, err
+		}
+		if owner == "" {
+			// The claim was released in the meantime.
+			continue
+		}
+		if owner == discUUID {
+			return false, nil
+		}
+		stale, err := s.claimStale(ctx, owner, claimedAt)
+		if err != nil {
+			return false, err
+		}
+		if !stale {
+			return false, &sourceClaimedError{discUUID: owner}
+		}
+		slog.WarnContext(ctx, "Replacing stale source claim", "claim_id", claimID, "owner", owner)
+		err = s.temporalClient.TerminateWorkflow(ctx, claimID, "", fmt.Sprintf("disc %s is no longer running", owner))
+		var notFoundErr *serviceerror.NotFound
+		if err != nil && !errors.As(err, &notFoundErr) {
+			return false, fmt.Errorf("failed to end stale source claim: %w", err)
+		}
+	}
+	return false, fmt.Errorf("failed to claim source: claim %s keeps being taken", claimID)
+}
+
+// claimOwner returns the UUID of the disc holding a claim, and when the claim was made.  It returns an
+// empty UUID if the claim is no longer held.
+func (s *Server) claimOwner(ctx context.Context, claimID string) (owner string, claimedAt time.Time, err error) {
+	claimResp, err := s.temporalClient.DescribeWorkflowExecution(ctx, claimID, "")
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to describe source claim: %w", err)
+	}
+	claimInfo := claimResp.GetWorkflowExecutionInfo()
+	if claimInfo.GetStatus() != enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		return "", time.Time{}, nil
+	}
+	payload := claimInfo.GetMemo().GetFields()[vwdisc.MemoClaimDiscUUID]
+	if payload == nil {
+		return "", time.Time{}, fmt.Errorf("source claim %s does not name its disc", claimID)
+	}
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &owner); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode disc of source claim %s: %w", claimID, err)
+	}
+	return owner, claimInfo.GetStartTime().AsTime(), nil
+}
+
+// claimStale reports whether a claim made at claimedAt by the disc owner is stale, because the disc is
+// no longer running or was never started.
+func (s *Server) claimStale(ctx context.Context, owner string, claimedAt time.Time) (bool, error) {
+	discResp, err := s.temporalClient.DescribeWorkflowExecution(ctx, owner, "")
+	var notFoundErr *serviceerror.NotFound
+	switch {
+	case errors.As(err, &notFoundErr):
+		return time.Since(claimedAt) > claimStartGrace, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to describe disc holding source claim: %w", err)
+	}
+	return discResp.GetWorkflowExecutionInfo().GetStatus() != enums.WORKFLOW_EXECUTION_STATUS_RUNNING, nil
+}
+
+// releaseSource gives up a claim made for a disc that could not be started.
+func (s *Server) releaseSource(ctx context.Context, claimID string) {
+	if err := s.temporalClient.SignalWorkflow(ctx, claimID, "", vwdisc.SignalReleaseSource, nil); err != nil {
+		slog.ErrorContext(ctx, "Failed to release source claim", "claim_id", claimID, internal.LogKeyError, err)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+	"github.com/krelinga/video-workflows/vwrest"
+	"github.com/stretchr/testify/mock"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/mocks"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testSourcePath = "/nas/media/inbox/disc1"
+
+var testClaimID = vwdisc.SourceClaimID(testSourcePath)
+
+// expectClaim expects the source to be claimed for id, failing with err.
+func (s *ServerTestSuite) expectClaim(id uuid.UUID, err error) {
+	s.client.On("ExecuteWorkflow", mock.Anything,
+		mock.MatchedBy(func(options client.StartWorkflowOptions) bool {
+			return options.ID == testClaimID && options.Memo[vwdisc.MemoClaimDiscUUID] == id.String()
+		}),
+		mock.Anything,
+	).Return(mocks.NewWorkflowRun(s.T()), err).Once()
+}
+
+// expectStart expects the disc workflow to be started for id, failing with err.
+func (s *ServerTestSuite) expectStart(id uuid.UUID, err error) {
+	s.client.On("ExecuteWorkflow", mock.Anything,
+		mock.MatchedBy(func(options client.StartWorkflowOptions) bool { return options.ID == id.String() }),
+		mock.Anything,
+		mock.MatchedBy(func(params vwdisc.Params) bool { return params.SourceClaimID == testClaimID }),
+	).Return(mocks.NewWorkflowRun(s.T()), err).Once()
+}
+
+// describeClaim makes the claim on the source belong to owner, made at startTime.
+func (s *ServerTestSuite) describeClaim(owner uuid.UUID, startTime time.Time) {
+	payload, err := converter.GetDefaultDataConverter().ToPayload(owner.String())
+	s.Require().NoError(err)
+	s.client.On("DescribeWorkflowExecution", mock.Anything, testClaimID, "").Return(&workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{
+			Status:    enums.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			StartTime: timestamppb.New(startTime),
+			Memo:      &commonpb.Memo{Fields: map[string]*commonpb.Payload{vwdisc.MemoClaimDiscUUID: payload}},
+		},
+	}, nil).Once()
+}
+
+func (s *ServerTestSuite) createDisc(id uuid.UUID) vwrest.CreateDiscResponseObject {
+	resp, err := s.server.CreateDisc(s.ctx, vwrest.CreateDiscRequestObject{
+		Body: &vwrest.CreateDiscRequest{Uuid: id, Path: testSourcePath},
+	})
+	s.Require().NoError(err)
+	return resp
+}
+
+var errClaimed = serviceerror.NewWorkflowExecutionAlreadyStarted("workflow already running", "", "claim-run-id")
+
+func (s *ServerTestSuite) Test_CreateDisc_ClaimsSource() {
+	id := uuid.New()
+	s.expectClaim(id, nil)
+	s.expectStart(id, nil)
+
+	resp := s.createDisc(id)
+
+	s.IsType(vwrest.CreateDisc201JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_CreateDisc_SourceAlreadyClaimed() {
+	id, existing := uuid.New(), uuid.New()
+	s.expectClaim(id, errClaimed)
+	s.describeClaim(existing, time.Now())
+	s.describeDisc(existing, enums.WORKFLOW_EXECUTION_STATUS_RUNNING)
+
+	resp := s.createDisc(id)
+
+	s.Require().IsType(vwrest.CreateDisc409JSONResponse{}, resp)
+	s.Contains(resp.(vwrest.CreateDisc409JSONResponse).Message, existing.String())
+}
+
+func (s *ServerTestSuite) Test_CreateDisc_SourceClaimedBeforeDiscStarted() {
+	id, existing := uuid.New(), uuid.New()
+	s.expectClaim(id, errClaimed)
+	s.describeClaim(existing, time.Now())
+	s.client.On("DescribeWorkflowExecution", mock.Anything, existing.String(), "").
+		Return(nil, serviceerror.NewNotFound("workflow not found")).Once()
+
+	resp := s.createDisc(id)
+
+	s.IsType(vwrest.CreateDisc409JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_CreateDisc_SameDiscRepeated() {
+	id := uuid.New()
+	s.expectClaim(id, errClaimed)
+	s.describeClaim(id, time.Now())
+	s.expectStart(id, serviceerror.NewWorkflowExecutionAlreadyStarted("workflow already running", "", "run-id"))
+
+	resp := s.createDisc(id)
+
+	// The claim still belongs to the disc, so it is not released.
+	s.Require().IsType(vwrest.CreateDisc409JSONResponse{}, resp)
+	s.Contains(resp.(vwrest.CreateDisc409JSONResponse).Message, "already exists")
+}
+
+func (s *ServerTestSuite) Test_CreateDisc_ReplacesStaleClaim() {
+	id, existing := uuid.New(), uuid.New()
+	s.expectClaim(id, errClaimed)
+	s.describeClaim(existing, time.Now().Add(-time.Hour))
+	s.describeDisc(existing, enums.WORKFLOW_EXECUTION_STATUS_TERMINATED)
+	s.client.On("TerminateWorkflow", mock.Anything, testClaimID, "", mock.Anything).Return(nil).Once()
+	s.expectClaim(id, nil)
+	s.expectStart(id, nil)
+
+	resp := s.createDisc(id)
+
+	s.IsType(vwrest.CreateDisc201JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_CreateDisc_ReleasesClaimWhenStartFails() {
+	id := uuid.New()
+	s.expectClaim(id, nil)
+	s.expectStart(id, errors.New("connection refused"))
+	s.client.On("SignalWorkflow", mock.Anything, testClaimID, "", vwdisc.SignalReleaseSource, nil).Return(nil).Once()
+
+	resp := s.createDisc(id)
+
+	s.IsType(vwrest.CreateDisc500JSONResponse{}, resp)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+	"github.com/krelinga/video-workflows/vwrest"
+)
+
+// titleNumberPattern matches the title number in the names MakeMKV gives the files it rips, such as
+// title_t03.mkv.
+var titleNumberPattern = regexp.MustCompile(`_t(\d+)\.[^.]+$`)
+
+// discFiles converts the per-file state of a disc for the API, ordered by title number and then by
+// relative path so that the order does not change between requests.  Files without a title number
+// come last.
+func discFiles(state vwdisc.State) []vwrest.DiscWorkflowFile {
+	files := make([]vwrest.DiscWorkflowFile, 0, len(state.Files))
+	for filePath, fileInfo := range state.Files {
+		relativePath := filepath.Base(filePath)
+		if state.Directory != "" {
+			if rel, err := filepath.Rel(state.Directory, filePath); err == nil {
+				relativePath = rel
+			}
+		}
+		files = append(files, vwrest.DiscWorkflowFile{
+			Id:                      fileID(relativePath),
+			Filename:                filePath,
+			Name:                    filepath.Base(filePath),
+			RelativePath:            relativePath,
+			SizeBytes:               fileInfo.SizeBytes,
+			DurationSeconds:         fileInfo.DurationSeconds,
+			ChapterDurationsSeconds: fileInfo.ChapterDurationsSeconds,
+			PreviewPath:             fileInfo.PreviewPath,
+		})
+	}
+	slices.SortFunc(files, compareFiles)
+	return files
+}
+
+func compareFiles(a, b vwrest.DiscWorkflowFile) int {
+	aNumber, aOK := titleNumber(a.Name)
+	bNumber, bOK := titleNumber(b.Name)
+	switch {
+	case aOK && !bOK:
+		return -1
+	case !aOK && bOK:
+		return 1
+	}
+	return cmp.Or(cmp.Compare(aNumber, bNumber), cmp.Compare(a.RelativePath, b.RelativePath))
+}
+
+// titleNumber returns the title number in a file name, if it has one.
+func titleNumber(name string) (int, bool) {
+	match := titleNumberPattern.FindStringSubmatch(name)
+	if match == nil {
+		return 0, false
+	}
+	number, err := strconv.Atoi(match[1])
+	return number, err == nil
+}
+
+// fileID derives a file's ID from its path relative to the disc's directory, so that it stays the same
+// wherever the library is mounted.
+func fileID(relativePath string) string {
+	sum := sha256.Sum256([]byte(relativePath))
+	return hex.EncodeToString(sum[:8])
+}
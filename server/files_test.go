@@ -0,0 +1,56 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+)
+
+func TestDiscFiles(t *testing.T) {
+	size := int64(1024)
+	state := vwdisc.State{
+		Directory: "/nas/media/library/disc",
+		Files: map[string]vwdisc.FileState{
+			"/nas/media/library/disc/title_t10.mkv": {},
+			"/nas/media/library/disc/bonus.mkv":     {},
+			"/nas/media/library/disc/title_t02.mkv": {SizeBytes: &size},
+			"/nas/media/library/disc/extras/a.mkv":  {},
+		},
+	}
+
+	// Map iteration order varies, so check the order holds over several calls.
+	want := []string{"title_t02.mkv", "title_t10.mkv", "bonus.mkv", "extras/a.mkv"}
+	for range 5 {
+		var order []string
+		for _, file := range discFiles(state) {
+			order = append(order, file.RelativePath)
+		}
+		if !slices.Equal(order, want) {
+			t.Fatalf("expected %v, got %v", want, order)
+		}
+	}
+
+	first := discFiles(state)[0]
+	if first.Name != "title_t02.mkv" || first.Filename != "/nas/media/library/disc/title_t02.mkv" {
+		t.Errorf("unexpected file identity %+v", first)
+	}
+	if first.SizeBytes == nil || *first.SizeBytes != size {
+		t.Errorf("expected size %d, got %v", size, first.SizeBytes)
+	}
+	if first.Id == "" || first.Id != fileID("title_t02.mkv") {
+		t.Errorf("expected the ID to derive from the relative path, got %q", first.Id)
+	}
+	if nested := discFiles(state)[3]; nested.Name != "a.mkv" {
+		t.Errorf("expected the base name of a nested file, got %q", nested.Name)
+	}
+}
+
+func TestDiscFiles_NoDirectory(t *testing.T) {
+	// States recorded before the disc's directory was kept fall back to base names.
+	files := discFiles(vwdisc.State{Files: map[string]vwdisc.FileState{"/nas/media/library/disc/title_t00.mkv": {}}})
+
+	if len(files) != 1 || files[0].RelativePath != "title_t00.mkv" {
+		t.Errorf("expected the base name as relative path, got %+v", files)
+	}
+}
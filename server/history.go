@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/vwrest"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/converter"
+)
+
+// GetDiscHistory returns a timeline of a disc workflow built from its Temporal event history.
+func (s *Server) GetDiscHistory(ctx context.Context, request vwrest.GetDiscHistoryRequestObject) (vwrest.GetDiscHistoryResponseObject, error) {
+	workflowID := request.Uuid.String()
+	ctx = internal.WithLogAttrs(ctx, internal.LogKeyDiscUUID, workflowID, internal.LogKeyWorkflowID, workflowID)
+
+	var events []*historypb.HistoryEvent
+	iter := s.temporalClient.GetWorkflowHistory(ctx, workflowID, "", false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			var notFoundErr *serviceerror.NotFound
+			if errors.As(err, &notFoundErr) {
+				return vwrest.GetDiscHistory404JSONResponse{
+					Code:    "NOT_FOUND",
+					Message: fmt.Sprintf("workflow with UUID %s not found", workflowID),
+				}, nil
+			}
+			slog.ErrorContext(ctx, "Failed to read disc workflow history", internal.LogKeyError, err)
+			return vwrest.GetDiscHistory500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to read workflow history: %v", err),
+			}, nil
+		}
+		events = append(events, event)
+	}
+
+	return vwrest.GetDiscHistory200JSONResponse{
+		Uuid:    request.Uuid,
+		Entries: buildTimeline(events),
+	}, nil
+}
+
+// Statuses of timeline entries, beyond the workflow event names used for workflow entries.
+const (
+	timelineScheduled = "scheduled"
+	timelineRunning   = "running"
+	timelineCompleted = "completed"
+	timelineFailed    = "failed"
+	timelineTimedOut  = "timedOut"
+	timelineCanceled  = "canceled"
+	timelineReceived  = "received"
+)
+
+// fileParams lists, in order of preference, the activity parameters that name the file or directory an
+// activity works on.
+var fileParams = []string{"video_path", "input_path", "source_path", "directory_path", "path"}
+
+// timeline accumulates entries while walking a workflow's history.  Activities and updates are keyed by
+// the event that opened them so that later events can fill them in.
+type timeline struct {
+	entries    []*vwrest.DiscHistoryEntry
+	activities map[int64]*vwrest.DiscHistoryEntry
+	updates    map[string]*vwrest.DiscHistoryEntry
+	startedAt  time.Time
+	// stage is the change ID of the most recent version marker, which names the stage that is running.
+	stage string
+}
+
+// buildTimeline converts a disc workflow's history into timeline entries, in the order they began.
+func buildTimeline(events []*historypb.HistoryEvent) []vwrest.DiscHistoryEntry {
+	t := &timeline{
+		activities: make(map[int64]*vwrest.DiscHistoryEntry),
+		updates:    make(map[string]*vwrest.DiscHistoryEntry),
+	}
+	for _, event := range events {
+		t.add(event)
+	}
+	entries := make([]vwrest.DiscHistoryEntry, 0, len(t.entries))
+	for _, entry := range t.entries {
+		entries = append(entries, *entry)
+	}
+	return entries
+}
+
+func (t *timeline) open(entry *vwrest.DiscHistoryEntry) *vwrest.DiscHistoryEntry {
+	t.entries = append(t.entries, entry)
+	return entry
+}
+
+func (t *timeline) add(event *historypb.HistoryEvent) {
+	at := event.GetEventTime().AsTime()
+	switch event.GetEventType() {
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+		t.startedAt = at
+		t.open(&vwrest.DiscHistoryEntry{Kind: vwrest.DiscHistoryEntryKindWorkflow, Name: "started", Status: "started", ScheduledAt: &at})
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		t.closeWorkflow("completed", at, "")
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		t.closeWorkflow("failed", at, failureMessage(event.GetWorkflowExecutionFailedEventAttributes().GetFailure()))
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		t.closeWorkflow("timedOut", at, "")
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		t.closeWorkflow("canceled", at, "")
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		t.closeWorkflow("terminated", at, event.GetWorkflowExecutionTerminatedEventAttributes().GetReason())
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		t.closeWorkflow("continuedAsNew", at, "")
+
+	case enums.EVENT_TYPE_MARKER_RECORDED:
+		attrs := event.GetMarkerRecordedEventAttributes()
+		if attrs.GetMarkerName() != "Version" {
+			return
+		}
+		var changeID string
+		if payloads := attrs.GetDetails()["change-id"].GetPayloads(); len(payloads) > 0 {
+			if err := converter.GetDefaultDataConverter().FromPayload(payloads[0], &changeID); err == nil {
+				t.stage = changeID
+			}
+		}
+
+	case enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+		attrs := event.GetActivityTaskScheduledEventAttributes()
+		t.activities[event.GetEventId()] = t.open(&vwrest.DiscHistoryEntry{
+			Kind:        vwrest.DiscHistoryEntryKindActivity,
+			Name:        attrs.GetActivityType().GetName(),
+			Stage:       optionalString(t.stage),
+			File:        optionalString(activityFile(attrs.GetInput())),
+			Status:      timelineScheduled,
+			ScheduledAt: &at,
+		})
+	case enums.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+		attrs := event.GetActivityTaskStartedEventAttributes()
+		if entry, ok := t.activities[attrs.GetScheduledEventId()]; ok {
+			attempt := int(attrs.GetAttempt())
+			entry.Status = timelineRunning
+			entry.StartedAt = &at
+			entry.Attempt = &attempt
+			// The failure of the previous attempt, if this one is a retry.
+			entry.Error = optionalString(failureMessage(attrs.GetLastFailure()))
+		}
+	case enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+		t.closeActivity(event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId(), timelineCompleted, at, nil)
+	case enums.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+		attrs := event.GetActivityTaskFailedEventAttributes()
+		t.closeActivity(attrs.GetScheduledEventId(), timelineFailed, at, attrs.GetFailure())
+	case enums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+		attrs := event.GetActivityTaskTimedOutEventAttributes()
+		t.closeActivity(attrs.GetScheduledEventId(), timelineTimedOut, at, attrs.GetFailure())
+	case enums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		t.closeActivity(event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId(), timelineCanceled, at, nil)
+
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+		t.open(&vwrest.DiscHistoryEntry{
+			Kind:        vwrest.DiscHistoryEntryKindSignal,
+			Name:        event.GetWorkflowExecutionSignaledEventAttributes().GetSignalName(),
+			Status:      timelineReceived,
+			ScheduledAt: &at,
+			ClosedAt:    &at,
+		})
+
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_ACCEPTED:
+		request := event.GetWorkflowExecutionUpdateAcceptedEventAttributes().GetAcceptedRequest()
+		t.updates[request.GetMeta().GetUpdateId()] = t.open(&vwrest.DiscHistoryEntry{
+			Kind:        vwrest.DiscHistoryEntryKindUpdate,
+			Name:        request.GetInput().GetName(),
+			Stage:       optionalString(t.stage),
+			Status:      timelineRunning,
+			ScheduledAt: &at,
+		})
+	case enums.EVENT_TYPE_WORKFLOW_EXECUTION_UPDATE_COMPLETED:
+		attrs := event.GetWorkflowExecutionUpdateCompletedEventAttributes()
+		entry, ok := t.updates[attrs.GetMeta().GetUpdateId()]
+		if !ok {
+			return
+		}
+		entry.Status = timelineCompleted
+		if failure := attrs.GetOutcome().GetFailure(); failure != nil {
+			entry.Status = timelineFailed
+			entry.Error = optionalString(failureMessage(failure))
+		}
+		closeEntry(entry, at)
+	}
+}
+
+func (t *timeline) closeWorkflow(status string, at time.Time, errorMessage string) {
+	entry := t.open(&vwrest.DiscHistoryEntry{
+		Kind:   vwrest.DiscHistoryEntryKindWorkflow,
+		Name:   status,
+		Status: status,
+		Error:  optionalString(errorMessage),
+	})
+	if !t.startedAt.IsZero() {
+		entry.ScheduledAt = &t.startedAt
+	}
+	closeEntry(entry, at)
+}
+
+func (t *timeline) closeActivity(scheduledEventID int64, status string, at time.Time, failure *failurepb.Failure) {
+	entry, ok := t.activities[scheduledEventID]
+	if !ok {
+		return
+	}
+	entry.Status = status
+	entry.Error = optionalString(failureMessage(failure))
+	closeEntry(entry, at)
+}
+
+// closeEntry records when entry finished and how long it took.
+func closeEntry(entry *vwrest.DiscHistoryEntry, at time.Time) {
+	entry.ClosedAt = &at
+	if entry.ScheduledAt != nil {
+		duration := at.Sub(*entry.ScheduledAt).Seconds()
+		entry.DurationSeconds = &duration
+	}
+}
+
+// failureMessage flattens a failure and its causes into one message.
+func failureMessage(failure *failurepb.Failure) string {
+	var message string
+	for ; failure != nil; failure = failure.GetCause() {
+		if failure.GetMessage() == "" {
+			continue
+		}
+		if message != "" {
+			message += ": "
+		}
+		message += failure.GetMessage()
+	}
+	return message
+}
+
+// activityFile picks the file or directory out of an activity's input, or returns "" if it has none.
+func activityFile(input *commonpb.Payloads) string {
+	payloads := input.GetPayloads()
+	if len(payloads) == 0 {
+		return ""
+	}
+	var params map[string]any
+	if err := converter.GetDefaultDataConverter().FromPayload(payloads[0], &params); err != nil {
+		return ""
+	}
+	for _, key := range fileParams {
+		if path, ok := params[key].(string); ok && path != "" {
+			return path
+		}
+	}
+	return ""
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
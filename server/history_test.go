@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-workflows/vwrest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+)
+
+// testHistory is a recorded disc workflow in which one file's video info and another's preview fail.
+const testHistory = "../internal/workflows/vwdisc/testdata/histories/v2_partial_failures.json"
+
+func findEntry(t *testing.T, entries []vwrest.DiscHistoryEntry, name, file string) vwrest.DiscHistoryEntry {
+	t.Helper()
+	for _, entry := range entries {
+		if entry.Name == name && (file == "" || entry.File != nil && *entry.File == file) {
+			return entry
+		}
+	}
+	t.Fatalf("no %s entry for %q", name, file)
+	return vwrest.DiscHistoryEntry{}
+}
+
+func TestBuildTimeline(t *testing.T) {
+	f, err := os.Open(testHistory)
+	require.NoError(t, err)
+	defer f.Close()
+	hist, err := client.HistoryFromJSON(f, client.HistoryJSONOptions{})
+	require.NoError(t, err)
+
+	entries := buildTimeline(hist.GetEvents())
+
+	require.NotEmpty(t, entries)
+	assert.Equal(t, vwrest.DiscHistoryEntry{Kind: vwrest.DiscHistoryEntryKindWorkflow, Name: "started", Status: "started", ScheduledAt: entries[0].ScheduledAt}, entries[0])
+	last := entries[len(entries)-1]
+	assert.Equal(t, vwrest.DiscHistoryEntryKindWorkflow, last.Kind)
+	assert.Equal(t, "completed", last.Status)
+	assert.NotNil(t, last.DurationSeconds)
+
+	rename := findEntry(t, entries, "RenameFile", "/nas/media/inbox/disc1")
+	assert.Equal(t, vwrest.DiscHistoryEntryKindActivity, rename.Kind)
+	assert.Equal(t, "move-directory", *rename.Stage)
+	assert.Equal(t, timelineCompleted, rename.Status)
+	assert.Equal(t, 1, *rename.Attempt)
+	require.NotNil(t, rename.DurationSeconds)
+	assert.GreaterOrEqual(t, *rename.DurationSeconds, 0.0)
+
+	info := findEntry(t, entries, "GetVideoInfo", "/nas/media/library/550e8400-e29b-41d4-a716-446655440000/title_t01.mkv")
+	assert.Equal(t, "diagnostics", *info.Stage)
+	assert.Equal(t, timelineFailed, info.Status)
+	require.NotNil(t, info.Error)
+	assert.Contains(t, *info.Error, "fake info failure")
+
+	preview := findEntry(t, entries, "Transcode", "/nas/media/library/550e8400-e29b-41d4-a716-446655440000/title_t02.mkv")
+	assert.Equal(t, timelineFailed, preview.Status)
+
+	save := findEntry(t, entries, "SaveDisc", "")
+	assert.Equal(t, "save-result", *save.Stage)
+	assert.Nil(t, save.File)
+}
+
+func (s *ServerTestSuite) Test_GetDiscHistory_NotFound() {
+	id := uuid.New()
+	iter := mocks.NewHistoryEventIterator(s.T())
+	iter.On("HasNext").Return(true).Once()
+	iter.On("Next").Return(nil, serviceerror.NewNotFound("workflow not found")).Once()
+	s.client.On("GetWorkflowHistory", mock.Anything, id.String(), "", false, mock.Anything).Return(iter).Once()
+
+	resp, err := s.server.GetDiscHistory(s.ctx, vwrest.GetDiscHistoryRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.GetDiscHistory404JSONResponse{}, resp)
+}
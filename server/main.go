@@ -1,39 +1,80 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
 
 	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/vwactivity"
+	"github.com/krelinga/video-workflows/internal/vwstore"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/worker"
 )
 
 func main() {
 	if err := mainImpl(); err != nil {
-		log.Fatal(err)
+		slog.Error("Exiting with error", internal.LogKeyError, err)
+		os.Exit(1)
 	}
 }
 
 func mainImpl() error {
 	config := internal.NewServerConfigFromEnv()
 
+	// Set up logging
+	logger := internal.NewLogger(config.Logging, nil)
+	slog.SetDefault(logger)
+
+	// Set up tracing
+	tracerProvider, err := internal.NewTracerProvider(context.Background(), config.Tracing, "video-workflows-server")
+	if err != nil {
+		return fmt.Errorf("failed to create tracer provider: %w", err)
+	}
+	defer tracerProvider.Shutdown(context.Background())
+	tracingInterceptor, err := internal.NewTracingInterceptor()
+	if err != nil {
+		return fmt.Errorf("failed to create tracing interceptor: %w", err)
+	}
+
 	// Create Temporal client
 	temporalClient, err := client.Dial(client.Options{
-		HostPort: fmt.Sprintf("%s:%d", config.Temporal.Host, config.Temporal.Port),
+		HostPort:     fmt.Sprintf("%s:%d", config.Temporal.Host, config.Temporal.Port),
+		Interceptors: []interceptor.ClientInterceptor{tracingInterceptor},
+		Logger:       internal.NewTemporalLogger(logger),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create Temporal client: %w", err)
 	}
 	defer temporalClient.Close()
 
+	// Open the disc store and serve the activity that writes to it.
+	var store *vwstore.Store
+	if config.StorePath != "" {
+		store, err = vwstore.Open(config.StorePath)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		storeWorker := worker.New(temporalClient, config.TaskQueues.Store, worker.Options{})
+		storeDeps := &vwactivity.StoreDeps{Store: store}
+		storeWorker.RegisterActivity(storeDeps.SaveDisc)
+		if err := storeWorker.Start(); err != nil {
+			return fmt.Errorf("failed to start store worker: %w", err)
+		}
+		defer storeWorker.Stop()
+	}
+
 	// Create server with library path
-	srv := NewServer(temporalClient, config.LibraryPath, config)
+	srv := NewServer(temporalClient, config.LibraryPath, config, store)
 
 	// Start HTTP server
 	addr := ":8080"
-	log.Printf("Starting server on %s", addr)
-	if err := http.ListenAndServe(addr, srv.Handler()); err != nil {
+	slog.Info("Starting server", "addr", addr)
+	if err := http.ListenAndServe(addr, internal.NewTracingHandler(srv.Handler(), "video-workflows-server")); err != nil {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
 
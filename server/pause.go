@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+	"github.com/krelinga/video-workflows/vwrest"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+)
+
+var (
+	errDiscNotFound = errors.New("disc workflow not found")
+	errDiscClosed   = errors.New("disc workflow has already finished")
+)
+
+// PauseDisc stops a disc workflow from starting any more activities.
+func (s *Server) PauseDisc(ctx context.Context, request vwrest.PauseDiscRequestObject) (vwrest.PauseDiscResponseObject, error) {
+	workflowID := request.Uuid.String()
+	ctx = internal.WithLogAttrs(ctx, internal.LogKeyDiscUUID, workflowID, internal.LogKeyWorkflowID, workflowID)
+
+	err := s.signalDisc(ctx, workflowID, vwdisc.SignalPause)
+	switch {
+	case errors.Is(err, errDiscNotFound):
+		return vwrest.PauseDisc404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("workflow with UUID %s not found", workflowID),
+		}, nil
+	case errors.Is(err, errDiscClosed):
+		return vwrest.PauseDisc409JSONResponse{
+			Code:    "CONFLICT",
+			Message: fmt.Sprintf("workflow with UUID %s has already finished", workflowID),
+		}, nil
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to pause disc workflow", internal.LogKeyError, err)
+		return vwrest.PauseDisc500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to pause workflow: %v", err),
+		}, nil
+	}
+	return vwrest.PauseDisc202Response{}, nil
+}
+
+// ResumeDisc lets a paused disc workflow start activities again.
+func (s *Server) ResumeDisc(ctx context.Context, request vwrest.ResumeDiscRequestObject) (vwrest.ResumeDiscResponseObject, error) {
+	workflowID := request.Uuid.String()
+	ctx = internal.WithLogAttrs(ctx, internal.LogKeyDiscUUID, workflowID, internal.LogKeyWorkflowID, workflowID)
+
+	err := s.signalDisc(ctx, workflowID, vwdisc.SignalResume)
+	switch {
+	case errors.Is(err, errDiscNotFound):
+		return vwrest.ResumeDisc404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("workflow with UUID %s not found", workflowID),
+		}, nil
+	case errors.Is(err, errDiscClosed):
+		return vwrest.ResumeDisc409JSONResponse{
+			Code:    "CONFLICT",
+			Message: fmt.Sprintf("workflow with UUID %s has already finished", workflowID),
+		}, nil
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to resume disc workflow", internal.LogKeyError, err)
+		return vwrest.ResumeDisc500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to resume workflow: %v", err),
+		}, nil
+	}
+	return vwrest.ResumeDisc202Response{}, nil
+}
+
+// signalDisc sends signalName to a running disc workflow.  Temporal reports signals to closed
+// workflows as not found, so the workflow is described first to tell errDiscNotFound and errDiscClosed
+// apart.
+func (s *Server) signalDisc(ctx context.Context, workflowID, signalName string) error {
+	var notFoundErr *serviceerror.NotFound
+	describeResp, err := s.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	if errors.As(err, &notFoundErr) {
+		return errDiscNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to describe workflow: %w", err)
+	}
+	if describeResp.GetWorkflowExecutionInfo().GetStatus() != enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		return errDiscClosed
+	}
+
+	slog.InfoContext(ctx, "Signalling disc workflow", "signal", signalName)
+	err = s.temporalClient.SignalWorkflow(ctx, workflowID, "", signalName, nil)
+	if errors.As(err, &notFoundErr) {
+		// The workflow finished after it was described.
+		return errDiscClosed
+	} else if err != nil {
+		return fmt.Errorf("failed to signal workflow: %w", err)
+	}
+	return nil
+}
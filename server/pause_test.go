@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+	"github.com/krelinga/video-workflows/vwrest"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+)
+
+func (s *ServerTestSuite) Test_PauseDisc() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	s.client.On("SignalWorkflow", mock.Anything, id.String(), "", vwdisc.SignalPause, nil).Return(nil).Once()
+
+	resp, err := s.server.PauseDisc(s.ctx, vwrest.PauseDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.PauseDisc202Response{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ResumeDisc() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	s.client.On("SignalWorkflow", mock.Anything, id.String(), "", vwdisc.SignalResume, nil).Return(nil).Once()
+
+	resp, err := s.server.ResumeDisc(s.ctx, vwrest.ResumeDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.ResumeDisc202Response{}, resp)
+}
+
+func (s *ServerTestSuite) Test_PauseDisc_NotFound() {
+	id := uuid.New()
+	s.client.On("DescribeWorkflowExecution", mock.Anything, id.String(), "").
+		Return(nil, serviceerror.NewNotFound("workflow not found")).Once()
+
+	resp, err := s.server.PauseDisc(s.ctx, vwrest.PauseDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.PauseDisc404JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_PauseDisc_Finished() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+
+	resp, err := s.server.PauseDisc(s.ctx, vwrest.PauseDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.PauseDisc409JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ResumeDisc_FinishedWhileSignalling() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	s.client.On("SignalWorkflow", mock.Anything, id.String(), "", vwdisc.SignalResume, nil).
+		Return(serviceerror.NewNotFound("workflow execution already completed")).Once()
+
+	resp, err := s.server.ResumeDisc(s.ctx, vwrest.ResumeDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.ResumeDisc409JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_PauseDisc_SignalFails() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	s.client.On("SignalWorkflow", mock.Anything, id.String(), "", vwdisc.SignalPause, nil).
+		Return(errors.New("connection refused")).Once()
+
+	resp, err := s.server.PauseDisc(s.ctx, vwrest.PauseDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.PauseDisc500JSONResponse{}, resp)
+}
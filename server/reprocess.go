@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/vwstore"
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+	"github.com/krelinga/video-workflows/vwrest"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+var errDiscRunning = errors.New("disc workflow is still running")
+
+// ReprocessDisc starts a new run of a finished disc workflow from the requested stage, carrying over the
+// state its previous run ended with.
+func (s *Server) ReprocessDisc(ctx context.Context, request vwrest.ReprocessDiscRequestObject) (vwrest.ReprocessDiscResponseObject, error) {
+	workflowID := request.Uuid.String()
+	ctx = internal.WithLogAttrs(ctx, internal.LogKeyDiscUUID, workflowID, internal.LogKeyWorkflowID, workflowID)
+
+	var stage vwdisc.Stage
+	switch request.Body.Stage {
+	case vwrest.ReprocessDiscRequestStageListFiles:
+		stage = vwdisc.StageListFiles
+	case vwrest.ReprocessDiscRequestStageDiagnostics:
+		stage = vwdisc.StageDiagnostics
+	case vwrest.ReprocessDiscRequestStageFinalTranscode:
+		stage = vwdisc.StageFinalTranscode
+	default:
+		return vwrest.ReprocessDisc400JSONResponse{
+			Code:    "BAD_REQUEST",
+			Message: fmt.Sprintf("unknown stage %q", request.Body.Stage),
+		}, nil
+	}
+
+	previous, err := s.previousState(ctx, workflowID)
+	switch {
+	case errors.Is(err, errDiscNotFound):
+		return vwrest.ReprocessDisc404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("workflow with UUID %s not found", workflowID),
+		}, nil
+	case errors.Is(err, errDiscRunning):
+		return vwrest.ReprocessDisc409JSONResponse{
+			Code:    "CONFLICT",
+			Message: fmt.Sprintf("workflow with UUID %s is still running", workflowID),
+		}, nil
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to get state of previous disc workflow", internal.LogKeyError, err)
+		return vwrest.ReprocessDisc500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+	if !previous.CanStartAt(stage) {
+		return vwrest.ReprocessDisc409JSONResponse{
+			Code:    "CONFLICT",
+			Message: fmt.Sprintf("workflow with UUID %s never got to stage %s", workflowID, stage),
+		}, nil
+	}
+
+	slog.InfoContext(ctx, "Reprocessing disc workflow", "stage", stage)
+	params := s.discParams(request.Uuid, "")
+	params.StartStage = stage
+	params.PreviousState = &previous
+	workflowOptions := client.StartWorkflowOptions{
+		ID:                    workflowID,
+		TaskQueue:             internal.TaskQueue,
+		WorkflowIDReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+	}
+	if _, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, vwdisc.Workflow, params); err != nil {
+		var alreadyStartedErr *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStartedErr) {
+			return vwrest.ReprocessDisc409JSONResponse{
+				Code:    "CONFLICT",
+				Message: fmt.Sprintf("workflow with UUID %s is still running", workflowID),
+			}, nil
+		}
+		slog.ErrorContext(ctx, "Failed to start disc workflow", internal.LogKeyError, err)
+		return vwrest.ReprocessDisc500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to start workflow: %v", err),
+		}, nil
+	}
+
+	return vwrest.ReprocessDisc202JSONResponse{
+		Uuid:   request.Uuid,
+		Status: vwrest.DiscPhaseCreated,
+	}, nil
+}
+
+// previousState returns the state that the latest run of a closed disc workflow ended with, falling back
+// to the store once Temporal has purged it.
+func (s *Server) previousState(ctx context.Context, workflowID string) (vwdisc.State, error) {
+	describeResp, err := s.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	var notFoundErr *serviceerror.NotFound
+	if errors.As(err, &notFoundErr) {
+		if s.store == nil {
+			return vwdisc.State{}, errDiscNotFound
+		}
+		record, err := s.store.Get(ctx, workflowID)
+		if errors.Is(err, vwstore.ErrNotFound) {
+			return vwdisc.State{}, errDiscNotFound
+		} else if err != nil {
+			return vwdisc.State{}, fmt.Errorf("failed to load disc from store: %w", err)
+		}
+		return storedState(record)
+	} else if err != nil {
+		return vwdisc.State{}, fmt.Errorf("failed to describe workflow: %w", err)
+	}
+
+	workflowInfo := describeResp.GetWorkflowExecutionInfo()
+	runID := workflowInfo.GetFirstRunId()
+	switch workflowInfo.GetStatus() {
+	case enums.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		return vwdisc.State{}, errDiscRunning
+	case enums.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		var state vwdisc.State
+		if err := s.temporalClient.GetWorkflow(ctx, workflowID, runID).Get(ctx, &state); err != nil {
+			return vwdisc.State{}, fmt.Errorf("failed to get workflow result: %w", err)
+		}
+		return state, nil
+	case enums.WORKFLOW_EXECUTION_STATUS_FAILED:
+		err := s.temporalClient.GetWorkflow(ctx, workflowID, runID).Get(ctx, nil)
+		if state, ok := vwdisc.StateFromError(err); ok {
+			return state, nil
+		}
+	}
+	// Only failures carry the state, so ask the closed workflow for it instead.
+	return s.queryState(ctx, workflowID, runID)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-workflows/internal/vwstore"
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+	"github.com/krelinga/video-workflows/vwrest"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/temporal"
+)
+
+// listedState is the state of a disc whose workflow listed its files.
+func listedState() vwdisc.State {
+	return vwdisc.State{
+		Phase:     vwdisc.PhaseGotFileDiagnostics,
+		Directory: "/nas/media/library/disc",
+		PhaseEnteredAt: map[vwdisc.Phase]time.Time{
+			vwdisc.PhaseDirectoryMoved:     time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+			vwdisc.PhaseFilesListed:        time.Date(2026, 1, 2, 15, 4, 6, 0, time.UTC),
+			vwdisc.PhaseGotFileDiagnostics: time.Date(2026, 1, 2, 16, 4, 5, 0, time.UTC),
+		},
+		Files: map[string]vwdisc.FileState{"/nas/media/library/disc/title.mkv": {}},
+	}
+}
+
+// completedRun makes the disc's run return state.
+func (s *ServerTestSuite) completedRun(state vwdisc.State) {
+	run := mocks.NewWorkflowRun(s.T())
+	run.On("Get", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*vwdisc.State) = state
+	}).Return(nil).Once()
+	s.client.On("GetWorkflow", mock.Anything, mock.Anything, "run-id").Return(run).Once()
+}
+
+// expectReprocess expects a new run of the disc from stage, carrying over previous.
+func (s *ServerTestSuite) expectReprocess(id uuid.UUID, stage vwdisc.Stage, previous vwdisc.State) {
+	s.client.On("ExecuteWorkflow", mock.Anything,
+		mock.MatchedBy(func(options client.StartWorkflowOptions) bool {
+			return options.ID == id.String() && options.WorkflowIDReusePolicy == enums.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE
+		}),
+		mock.Anything,
+		mock.MatchedBy(func(params vwdisc.Params) bool {
+			return params.UUID == id.String() && params.StartStage == stage &&
+				params.PreviousState != nil && params.PreviousState.Directory == previous.Directory
+		}),
+	).Return(mocks.NewWorkflowRun(s.T()), nil).Once()
+}
+
+func (s *ServerTestSuite) reprocess(id uuid.UUID, stage vwrest.ReprocessDiscRequestStage) vwrest.ReprocessDiscResponseObject {
+	resp, err := s.server.ReprocessDisc(s.ctx, vwrest.ReprocessDiscRequestObject{
+		Uuid: id,
+		Body: &vwrest.ReprocessDiscRequest{Stage: stage},
+	})
+	s.Require().NoError(err)
+	return resp
+}
+
+func (s *ServerTestSuite) Test_ReprocessDisc_Completed() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	s.completedRun(listedState())
+	s.expectReprocess(id, vwdisc.StageDiagnostics, listedState())
+
+	resp := s.reprocess(id, vwrest.ReprocessDiscRequestStageDiagnostics)
+
+	s.Require().IsType(vwrest.ReprocessDisc202JSONResponse{}, resp)
+	s.Equal(vwrest.DiscPhaseCreated, resp.(vwrest.ReprocessDisc202JSONResponse).Status)
+}
+
+func (s *ServerTestSuite) Test_ReprocessDisc_Failed() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_FAILED)
+	s.endedRun(temporal.NewApplicationErrorWithCause("failed to create preview directory", vwdisc.ErrorTypeDiscFailed, errors.New("disk full"), listedState()))
+	s.expectReprocess(id, vwdisc.StageListFiles, listedState())
+
+	resp := s.reprocess(id, vwrest.ReprocessDiscRequestStageListFiles)
+
+	s.IsType(vwrest.ReprocessDisc202JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ReprocessDisc_PurgedFromTemporal() {
+	id := uuid.New()
+	encoded, err := json.Marshal(listedState())
+	s.Require().NoError(err)
+	s.useStore(vwstore.Record{UUID: id.String(), Status: vwstore.StatusCompleted, State: encoded, ClosedAt: time.Now()})
+	s.client.On("DescribeWorkflowExecution", mock.Anything, id.String(), "").
+		Return(nil, serviceerror.NewNotFound("workflow not found")).Once()
+	s.expectReprocess(id, vwdisc.StageDiagnostics, listedState())
+
+	resp := s.reprocess(id, vwrest.ReprocessDiscRequestStageDiagnostics)
+
+	s.IsType(vwrest.ReprocessDisc202JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ReprocessDisc_Running() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_RUNNING)
+
+	resp := s.reprocess(id, vwrest.ReprocessDiscRequestStageDiagnostics)
+
+	s.IsType(vwrest.ReprocessDisc409JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ReprocessDisc_StageNotReached() {
+	id := uuid.New()
+	state := listedState()
+	delete(state.PhaseEnteredAt, vwdisc.PhaseFilesListed)
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_FAILED)
+	s.endedRun(temporal.NewApplicationErrorWithCause("failed to list video files", vwdisc.ErrorTypeDiscFailed, errors.New("permission denied"), state))
+
+	resp := s.reprocess(id, vwrest.ReprocessDiscRequestStageDiagnostics)
+
+	s.IsType(vwrest.ReprocessDisc409JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ReprocessDisc_NotFound() {
+	id := uuid.New()
+	s.client.On("DescribeWorkflowExecution", mock.Anything, id.String(), "").
+		Return(nil, serviceerror.NewNotFound("workflow not found")).Once()
+
+	resp := s.reprocess(id, vwrest.ReprocessDiscRequestStageDiagnostics)
+
+	s.IsType(vwrest.ReprocessDisc404JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ReprocessDisc_FinalTranscode() {
+	// Disc workflows do not transcode the main title yet, so no disc has reached that stage.
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	s.completedRun(listedState())
+
+	resp := s.reprocess(id, vwrest.ReprocessDiscRequestStageFinalTranscode)
+
+	s.IsType(vwrest.ReprocessDisc409JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ReprocessDisc_UnknownStage() {
+	resp := s.reprocess(uuid.New(), "upload")
+
+	s.IsType(vwrest.ReprocessDisc400JSONResponse{}, resp)
+}
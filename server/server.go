@@ -2,19 +2,25 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/krelinga/video-workflows/internal"
 	"github.com/krelinga/video-workflows/internal/vwactivity"
+	"github.com/krelinga/video-workflows/internal/vwstore"
 	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
 	"github.com/krelinga/video-workflows/vwrest"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 )
 
@@ -23,14 +29,20 @@ type Server struct {
 	temporalClient client.Client
 	libraryPath    string
 	config         *internal.ServerConfig
+	callbacks      *callbackLog
+	// store keeps the outcomes of closed disc workflows.  It is nil if no store is configured.
+	store *vwstore.Store
 }
 
-// NewServer creates a new Server with the given Temporal client and library path.
-func NewServer(temporalClient client.Client, libraryPath string, config *internal.ServerConfig) *Server {
+// NewServer creates a new Server with the given Temporal client, library path and disc store, which may
+// be nil.
+func NewServer(temporalClient client.Client, libraryPath string, config *internal.ServerConfig, store *vwstore.Store) *Server {
 	return &Server{
 		temporalClient: temporalClient,
 		libraryPath:    libraryPath,
 		config:         config,
+		callbacks:      newCallbackLog(callbackLogSize),
+		store:          store,
 	}
 }
 
@@ -41,12 +53,27 @@ func (s *Server) Handler() http.Handler {
 
 // CreateDisc starts a new disc workflow with the given UUID and path.
 func (s *Server) CreateDisc(ctx context.Context, request vwrest.CreateDiscRequestObject) (vwrest.CreateDiscResponseObject, error) {
-	params := vwdisc.Params{
-		UUID:           request.Body.Uuid.String(),
-		Path:           request.Body.Path,
-		LibraryPath:    s.libraryPath,
-		PreviewPath:    s.config.PreviewPath,
-		WebhookBaseURI: s.config.WebhookBaseURI,
+	ctx = internal.WithLogAttrs(ctx, internal.LogKeyDiscUUID, request.Body.Uuid.String(), internal.LogKeyWorkflowID, request.Body.Uuid.String())
+	slog.InfoContext(ctx, "Creating disc workflow", "path", request.Body.Path)
+	params := s.discParams(request.Body.Uuid, request.Body.Path)
+
+	// Claim the source directory, so that a second disc created from it cannot race this one to move it.
+	params.SourceClaimID = vwdisc.SourceClaimID(request.Body.Path)
+	claimed, err := s.claimSource(ctx, params.SourceClaimID, params.UUID)
+	if err != nil {
+		var claimedErr *sourceClaimedError
+		if errors.As(err, &claimedErr) {
+			slog.WarnContext(ctx, "Disc source is already being ingested", "existing_disc_uuid", claimedErr.discUUID)
+			return vwrest.CreateDisc409JSONResponse{
+				Code:    "CONFLICT",
+				Message: fmt.Sprintf("path %s is already being ingested by disc %s", request.Body.Path, claimedErr.discUUID),
+			}, nil
+		}
+		slog.ErrorContext(ctx, "Failed to claim disc source", internal.LogKeyError, err)
+		return vwrest.CreateDisc500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
 	}
 
 	workflowOptions := client.StartWorkflowOptions{
@@ -54,15 +81,20 @@ func (s *Server) CreateDisc(ctx context.Context, request vwrest.CreateDiscReques
 		TaskQueue: internal.TaskQueue,
 	}
 
-	_, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, vwdisc.Workflow, params)
+	_, err = s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, vwdisc.Workflow, params)
 	if err != nil {
+		if claimed {
+			s.releaseSource(ctx, params.SourceClaimID)
+		}
 		var alreadyStartedErr *serviceerror.WorkflowExecutionAlreadyStarted
 		if errors.As(err, &alreadyStartedErr) {
+			slog.WarnContext(ctx, "Disc workflow already exists")
 			return vwrest.CreateDisc409JSONResponse{
 				Code:    "CONFLICT",
 				Message: fmt.Sprintf("workflow with UUID %s already exists", request.Body.Uuid.String()),
 			}, nil
 		}
+		slog.ErrorContext(ctx, "Failed to start disc workflow", internal.LogKeyError, err)
 		return vwrest.CreateDisc500JSONResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: fmt.Sprintf("failed to start workflow: %v", err),
@@ -71,81 +103,135 @@ func (s *Server) CreateDisc(ctx context.Context, request vwrest.CreateDiscReques
 
 	return vwrest.CreateDisc201JSONResponse{
 		Uuid:   request.Body.Uuid,
-		Status: "created",
+		Status: vwrest.DiscPhaseCreated,
 	}, nil
 }
 
-// CompleteGetVideoInfoActivity completes a GetVideoInfo activity with the provided result or error.
-func (s *Server) CompleteGetVideoInfoActivity(ctx context.Context, request vwrest.CompleteGetVideoInfoActivityRequestObject) (vwrest.CompleteGetVideoInfoActivityResponseObject, error) {
-	log.Printf("Received CompleteGetVideoInfoActivity request: %+v", request.Body)
-	if request.Body.Result != nil {
-		log.Printf("Result: %+v", *request.Body.Result)
+// discParams returns the parameters for a disc workflow that ingests the disc at path.
+func (s *Server) discParams(id openapi_types.UUID, path string) vwdisc.Params {
+	params := vwdisc.Params{
+		UUID:                  id.String(),
+		Path:                  path,
+		LibraryPath:           s.libraryPath,
+		PreviewPath:           s.config.PreviewPath,
+		WebhookBaseURI:        s.config.WebhookBaseURI,
+		FilesystemTaskQueue:   s.config.TaskQueues.Filesystem,
+		RemoteTaskQueue:       s.config.TaskQueues.Remote,
+		MaxConcurrentPreviews: s.config.MaxConcurrentPreviews,
 	}
-
-	if request.Body.Error != nil {
-		err := s.temporalClient.CompleteActivity(ctx, request.Body.Token, nil, errors.New(*request.Body.Error))
-		if err != nil {
-			return vwrest.CompleteGetVideoInfoActivity500JSONResponse{
-				Code:    "INTERNAL_ERROR",
-				Message: fmt.Sprintf("failed to complete activity with error: %v", err),
-			}, nil
-		}
-		return vwrest.CompleteGetVideoInfoActivity200Response{}, nil
+	if s.store != nil {
+		params.StoreTaskQueue = s.config.TaskQueues.Store
 	}
+	return params
+}
 
-	if request.Body.Result == nil {
+// CompleteGetVideoInfoActivity completes a GetVideoInfo activity with the provided result or error.
+func (s *Server) CompleteGetVideoInfoActivity(ctx context.Context, request vwrest.CompleteGetVideoInfoActivityRequestObject) (vwrest.CompleteGetVideoInfoActivityResponseObject, error) {
+	ctx = jobLogContext(ctx, request.Body.Uuid)
+
+	token, err := s.parseCallbackToken(ctx, request.Body.Token, request.Body.Uuid)
+	if err != nil {
+		slog.WarnContext(ctx, "Rejecting GetVideoInfo completion callback", internal.LogKeyError, err)
 		return vwrest.CompleteGetVideoInfoActivity400JSONResponse{
 			Code:    "BAD_REQUEST",
-			Message: "either result or error must be provided",
+			Message: err.Error(),
 		}, nil
 	}
+	ctx = callbackLogContext(ctx, token)
+	slog.InfoContext(ctx, "Received GetVideoInfo completion", "has_result", request.Body.Result != nil, "has_error", request.Body.Error != nil)
 
-	var durationSeconds float64
-	if request.Body.Result.TotalDurationSeconds != nil {
-		durationSeconds = *request.Body.Result.TotalDurationSeconds
+	if s.callbacks.Contains(callbackGetVideoInfo, request.Body.Uuid) {
+		slog.InfoContext(ctx, "Ignoring duplicate GetVideoInfo completion")
+		return vwrest.CompleteGetVideoInfoActivity200Response{}, nil
 	}
 
-	result := vwactivity.VideoInfo{
-		DurationSeconds:  durationSeconds,
-		ChapterDurations: request.Body.Result.ChapterDurationsSeconds,
+	var result any
+	var activityErr error
+	if request.Body.Error != nil {
+		slog.WarnContext(ctx, "GetVideoInfo job failed", internal.LogKeyError, *request.Body.Error)
+		activityErr = vwactivity.JobFailedError(*request.Body.Error)
+	} else {
+		if request.Body.Result == nil {
+			return vwrest.CompleteGetVideoInfoActivity400JSONResponse{
+				Code:    "BAD_REQUEST",
+				Message: "either result or error must be provided",
+			}, nil
+		}
+
+		var durationSeconds float64
+		if request.Body.Result.TotalDurationSeconds != nil {
+			durationSeconds = *request.Body.Result.TotalDurationSeconds
+		}
+		info := vwactivity.VideoInfo{
+			DurationSeconds:  durationSeconds,
+			ChapterDurations: request.Body.Result.ChapterDurationsSeconds,
+		}
+		slog.DebugContext(ctx, "GetVideoInfo result", "duration_seconds", info.DurationSeconds, "chapter_count", len(info.ChapterDurations))
+		result = info
 	}
 
-	err := s.temporalClient.CompleteActivity(ctx, request.Body.Token, result, nil)
-	if err != nil {
+	err = s.completeActivity(ctx, token, result, activityErr)
+	switch {
+	case isActivityGone(err):
+		slog.WarnContext(ctx, "GetVideoInfo activity is no longer running", internal.LogKeyError, err)
+		return vwrest.CompleteGetVideoInfoActivity410JSONResponse{
+			Code:    "GONE",
+			Message: fmt.Sprintf("activity is no longer running: %v", err),
+		}, nil
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to complete GetVideoInfo activity", internal.LogKeyError, err)
 		return vwrest.CompleteGetVideoInfoActivity500JSONResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: fmt.Sprintf("failed to complete activity: %v", err),
 		}, nil
 	}
+	s.callbacks.Record(callbackGetVideoInfo, request.Body.Uuid)
 
 	return vwrest.CompleteGetVideoInfoActivity200Response{}, nil
 }
 
 // CompleteTranscodeActivity completes a Transcode activity with success or an error.
 func (s *Server) CompleteTranscodeActivity(ctx context.Context, request vwrest.CompleteTranscodeActivityRequestObject) (vwrest.CompleteTranscodeActivityResponseObject, error) {
-	log.Printf("Received CompleteTranscodeActivity request: %v", request.Body)
-	if request.Body.Error != nil {
-		log.Printf("CompleteTranscodeActivity Error: %s", *request.Body.Error)
+	ctx = jobLogContext(ctx, request.Body.Uuid)
+
+	token, err := s.parseCallbackToken(ctx, request.Body.Token, request.Body.Uuid)
+	if err != nil {
+		slog.WarnContext(ctx, "Rejecting Transcode completion callback", internal.LogKeyError, err)
+		return vwrest.CompleteTranscodeActivity400JSONResponse{
+			Code:    "BAD_REQUEST",
+			Message: err.Error(),
+		}, nil
 	}
+	ctx = callbackLogContext(ctx, token)
+	slog.InfoContext(ctx, "Received Transcode completion", "has_error", request.Body.Error != nil)
 
-	if request.Body.Error != nil {
-		err := s.temporalClient.CompleteActivity(ctx, request.Body.Token, nil, errors.New(*request.Body.Error))
-		if err != nil {
-			return vwrest.CompleteTranscodeActivity500JSONResponse{
-				Code:    "INTERNAL_ERROR",
-				Message: fmt.Sprintf("failed to complete activity with error: %v", err),
-			}, nil
-		}
+	if s.callbacks.Contains(callbackTranscode, request.Body.Uuid) {
+		slog.InfoContext(ctx, "Ignoring duplicate Transcode completion")
 		return vwrest.CompleteTranscodeActivity200Response{}, nil
 	}
 
-	err := s.temporalClient.CompleteActivity(ctx, request.Body.Token, nil, nil)
-	if err != nil {
+	var activityErr error
+	if request.Body.Error != nil {
+		slog.WarnContext(ctx, "Transcode job failed", internal.LogKeyError, *request.Body.Error)
+		activityErr = vwactivity.JobFailedError(*request.Body.Error)
+	}
+
+	err = s.completeActivity(ctx, token, nil, activityErr)
+	switch {
+	case isActivityGone(err):
+		slog.WarnContext(ctx, "Transcode activity is no longer running", internal.LogKeyError, err)
+		return vwrest.CompleteTranscodeActivity410JSONResponse{
+			Code:    "GONE",
+			Message: fmt.Sprintf("activity is no longer running: %v", err),
+		}, nil
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to complete Transcode activity", internal.LogKeyError, err)
 		return vwrest.CompleteTranscodeActivity500JSONResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: fmt.Sprintf("failed to complete activity: %v", err),
 		}, nil
 	}
+	s.callbacks.Record(callbackTranscode, request.Body.Uuid)
 
 	return vwrest.CompleteTranscodeActivity200Response{}, nil
 }
@@ -155,10 +241,29 @@ func (s *Server) TranscodeActivityHeartbeat(ctx context.Context, request vwrest.
 	progress := vwactivity.TranscodeProgress{
 		Percentage: request.Body.Progress,
 	}
-	log.Printf("Recording heartbeat for Transcode activity with token %v: %+v", request.Body.Token, progress)
+	ctx = jobLogContext(ctx, request.Body.Uuid)
 
-	err := s.temporalClient.RecordActivityHeartbeat(ctx, request.Body.Token, progress)
+	token, err := s.parseCallbackToken(ctx, request.Body.Token, request.Body.Uuid)
 	if err != nil {
+		slog.WarnContext(ctx, "Rejecting Transcode heartbeat callback", internal.LogKeyError, err)
+		return vwrest.TranscodeActivityHeartbeat400JSONResponse{
+			Code:    "BAD_REQUEST",
+			Message: err.Error(),
+		}, nil
+	}
+	ctx = callbackLogContext(ctx, token)
+	slog.DebugContext(ctx, "Recording Transcode heartbeat", "percentage", progress.Percentage)
+
+	err = s.recordHeartbeat(ctx, token, progress)
+	switch {
+	case isActivityGone(err):
+		slog.WarnContext(ctx, "Transcode activity is no longer running", internal.LogKeyError, err)
+		return vwrest.TranscodeActivityHeartbeat410JSONResponse{
+			Code:    "GONE",
+			Message: fmt.Sprintf("activity is no longer running: %v", err),
+		}, nil
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to record Transcode heartbeat", internal.LogKeyError, err)
 		return vwrest.TranscodeActivityHeartbeat500JSONResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: fmt.Sprintf("failed to record heartbeat: %v", err),
@@ -171,17 +276,31 @@ func (s *Server) TranscodeActivityHeartbeat(ctx context.Context, request vwrest.
 // GetDisc retrieves the current state of a disc workflow by UUID.
 func (s *Server) GetDisc(ctx context.Context, request vwrest.GetDiscRequestObject) (vwrest.GetDiscResponseObject, error) {
 	workflowID := request.Uuid.String()
+	ctx = internal.WithLogAttrs(ctx, internal.LogKeyDiscUUID, workflowID, internal.LogKeyWorkflowID, workflowID)
 
 	// Check if the workflow has completed and returned an error
 	describeResp, err := s.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
 	if err != nil {
 		var notFoundErr *serviceerror.NotFound
 		if errors.As(err, &notFoundErr) {
+			// Temporal may have purged the workflow's history; fall back to the store.
+			stored, err := s.storedDisc(ctx, request.Uuid)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to load disc from store", internal.LogKeyError, err)
+				return vwrest.GetDisc500JSONResponse{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				}, nil
+			}
+			if stored != nil {
+				return discResponse(*stored), nil
+			}
 			return vwrest.GetDisc404JSONResponse{
 				Code:    "NOT_FOUND",
 				Message: fmt.Sprintf("workflow with UUID %s not found", workflowID),
 			}, nil
 		}
+		slog.ErrorContext(ctx, "Failed to describe disc workflow", internal.LogKeyError, err)
 		return vwrest.GetDisc500JSONResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: fmt.Sprintf("failed to describe workflow: %v", err),
@@ -191,27 +310,38 @@ func (s *Server) GetDisc(ctx context.Context, request vwrest.GetDiscRequestObjec
 	// Check if workflow has completed with an error
 	workflowInfo := describeResp.GetWorkflowExecutionInfo()
 	if workflowInfo.Status != enums.WORKFLOW_EXECUTION_STATUS_RUNNING && workflowInfo.Status != enums.WORKFLOW_EXECUTION_STATUS_COMPLETED {
-		// Workflow ended with an error, was terminated, cancelled, etc.  Recover the error message.
+		// Workflow ended with an error, was terminated, cancelled, etc.  Recover the error message and
+		// whatever state the workflow reached before it ended.
 		workflow := s.temporalClient.GetWorkflow(ctx, workflowID, describeResp.WorkflowExecutionInfo.GetFirstRunId())
 		err := workflow.Get(ctx, nil)
 		errorMessage := fmt.Sprintf("workflow ended with status %s, error: %v", workflowInfo.Status.String(), err.Error())
+		state, ok := vwdisc.StateFromError(err)
+		if !ok {
+			// Only failures carry the state, so ask the closed workflow for it instead.
+			state, err = s.queryState(ctx, workflowID, describeResp.WorkflowExecutionInfo.GetFirstRunId())
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to query state of ended disc workflow", internal.LogKeyError, err)
+			}
+		}
 
-		return vwrest.GetDisc200JSONResponse{
-			Body: vwrest.DiscWorkflow{
-				Uuid:   request.Uuid,
-				Status: "failed",
-				Error:  &errorMessage,
-			},
-			Headers: vwrest.GetDisc200ResponseHeaders{
-				CacheControl: "no-cache, no-store, must-revalidate",
-				Pragma:       "no-cache",
-				Expires:      "0",
-			},
-		}, nil
+		return discResponse(vwrest.DiscWorkflow{
+			Uuid:           request.Uuid,
+			Status:         vwrest.DiscPhaseFailed,
+			PhaseEnteredAt: failedPhaseEnteredAt(state, workflowInfo.GetCloseTime().AsTime()),
+			Error:          &errorMessage,
+			Files:          discFiles(state),
+		}), nil
 	}
 
 	// The workflow did not return an error, so query for the state.
-	resp, err := s.temporalClient.QueryWorkflow(ctx, workflowID, describeResp.WorkflowExecutionInfo.GetFirstRunId(), vwdisc.QueryGetState)
+	state, err := s.queryState(ctx, workflowID, describeResp.WorkflowExecutionInfo.GetFirstRunId())
+	if err != nil && workflowInfo.Status == enums.WORKFLOW_EXECUTION_STATUS_COMPLETED {
+		// Querying a closed workflow needs a worker to replay it, but its outcome may have been stored.
+		if stored, storeErr := s.storedDisc(ctx, request.Uuid); storeErr == nil && stored != nil {
+			slog.WarnContext(ctx, "Failed to query completed disc workflow, using stored state", internal.LogKeyError, err)
+			return discResponse(*stored), nil
+		}
+	}
 	if err != nil {
 		var notFoundErr *serviceerror.NotFound
 		if errors.As(err, &notFoundErr) {
@@ -220,48 +350,157 @@ func (s *Server) GetDisc(ctx context.Context, request vwrest.GetDiscRequestObjec
 				Message: fmt.Sprintf("workflow with UUID %s not found", workflowID),
 			}, nil
 		}
+		slog.ErrorContext(ctx, "Failed to query disc workflow", internal.LogKeyError, err)
 		return vwrest.GetDisc500JSONResponse{
 			Code:    "INTERNAL_ERROR",
-			Message: fmt.Sprintf("failed to query workflow: %v", err),
+			Message: err.Error(),
 		}, nil
 	}
 
-	var state vwdisc.State
-	if err := resp.Get(&state); err != nil {
-		return vwrest.GetDisc500JSONResponse{
-			Code:    "INTERNAL_ERROR",
-			Message: fmt.Sprintf("failed to decode workflow state: %v", err),
-		}, nil
+	return discResponse(vwrest.DiscWorkflow{
+		Uuid:           request.Uuid,
+		Status:         discPhase(state),
+		PhaseEnteredAt: phaseEnteredAt(state),
+		Files:          discFiles(state),
+	}), nil
+}
+
+// discResponse wraps a disc for a successful GetDisc response.
+func discResponse(disc vwrest.DiscWorkflow) vwrest.GetDisc200JSONResponse {
+	return vwrest.GetDisc200JSONResponse{
+		Body: disc,
+		Headers: vwrest.GetDisc200ResponseHeaders{
+			CacheControl: "no-cache, no-store, must-revalidate",
+			Pragma:       "no-cache",
+			Expires:      "0",
+		},
+	}
+}
+
+// discPhase reports the phase of a disc whose workflow has not failed.
+func discPhase(state vwdisc.State) vwrest.DiscPhase {
+	if state.Phase == "" {
+		return vwrest.DiscPhaseRunning
+	}
+	return vwrest.DiscPhase(state.Phase)
+}
+
+// phaseEnteredAt converts the phase timestamps in state for the API.
+func phaseEnteredAt(state vwdisc.State) map[string]time.Time {
+	enteredAt := make(map[string]time.Time, len(state.PhaseEnteredAt)+1)
+	for phase, at := range state.PhaseEnteredAt {
+		enteredAt[string(phase)] = at
 	}
+	return enteredAt
+}
+
+// failedPhaseEnteredAt adds the failed phase, entered when the workflow ended at closedAt, to the phases
+// in state.
+func failedPhaseEnteredAt(state vwdisc.State, closedAt time.Time) map[string]time.Time {
+	enteredAt := phaseEnteredAt(state)
+	enteredAt[string(vwrest.DiscPhaseFailed)] = closedAt
+	return enteredAt
+}
 
-	// Derive status from state
-	status := "running"
-	if state.DirectoryMoved {
-		status = "directory_moved"
+// storedDisc loads a disc from the store.  It returns nil if there is no store or the disc is not in it.
+func (s *Server) storedDisc(ctx context.Context, id openapi_types.UUID) (*vwrest.DiscWorkflow, error) {
+	if s.store == nil {
+		return nil, nil
+	}
+	record, err := s.store.Get(ctx, id.String())
+	if errors.Is(err, vwstore.ErrNotFound) {
+		return nil, nil
 	}
-	if state.FilesListed {
-		status = "files_listed"
+	if err != nil {
+		return nil, err
 	}
-	if state.GotFileDiagnostics {
-		status = "got_file_diagnostics"
+	state, err := storedState(record)
+	if err != nil {
+		return nil, err
 	}
-	var files []vwrest.DiscWorkflowFile
-	for filePath, fileInfo := range state.Files {
-		files = append(files, vwrest.DiscWorkflowFile{
-			Filename:                filePath,
-			DurationSeconds:         fileInfo.DurationSeconds,
-			ChapterDurationsSeconds: fileInfo.ChapterDurationsSeconds,
-			PreviewPath:             fileInfo.PreviewPath,
+	disc := &vwrest.DiscWorkflow{
+		Uuid:           id,
+		Status:         discPhase(state),
+		PhaseEnteredAt: phaseEnteredAt(state),
+		Error:          record.Error,
+		Files:          discFiles(state),
+	}
+	if record.Status == vwstore.StatusFailed {
+		disc.Status = vwrest.DiscPhaseFailed
+		disc.PhaseEnteredAt = failedPhaseEnteredAt(state, record.ClosedAt)
+	}
+	return disc, nil
+}
+
+// storedState decodes the state kept in a store record.
+func storedState(record vwstore.Record) (vwdisc.State, error) {
+	var state vwdisc.State
+	if err := json.Unmarshal(record.State, &state); err != nil {
+		return vwdisc.State{}, fmt.Errorf("failed to decode stored state of disc %s: %w", record.UUID, err)
+	}
+	return state, nil
+}
+
+// discWorkflowQuery selects disc workflows in Temporal's visibility store.
+const discWorkflowQuery = "WorkflowType = 'Workflow'"
+
+// ListDiscs lists the disc workflows known to Temporal, followed by those only left in the store.
+func (s *Server) ListDiscs(ctx context.Context, request vwrest.ListDiscsRequestObject) (vwrest.ListDiscsResponseObject, error) {
+	discs := []vwrest.DiscWorkflow{}
+	seen := make(map[openapi_types.UUID]bool)
+	var pageToken []byte
+	for {
+		resp, err := s.temporalClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         discWorkflowQuery,
+			NextPageToken: pageToken,
 		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to list disc workflows", internal.LogKeyError, err)
+			return vwrest.ListDiscs500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to list workflows: %v", err),
+			}, nil
+		}
+		for _, execution := range resp.GetExecutions() {
+			id, err := uuid.Parse(execution.GetExecution().GetWorkflowId())
+			if err != nil || seen[id] {
+				continue
+			}
+			seen[id] = true
+			discs = append(discs, vwrest.DiscWorkflow{Uuid: id, Status: listStatus(execution.GetStatus())})
+		}
+		pageToken = resp.GetNextPageToken()
+		if len(pageToken) == 0 {
+			break
+		}
 	}
 
-	return vwrest.GetDisc200JSONResponse{
-		Body: vwrest.DiscWorkflow{
-			Uuid:   request.Uuid,
-			Status: status,
-			Files:  files,
-		},
-		Headers: vwrest.GetDisc200ResponseHeaders{
+	if s.store != nil {
+		records, err := s.store.List(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to list stored discs", internal.LogKeyError, err)
+			return vwrest.ListDiscs500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		for _, record := range records {
+			id, err := uuid.Parse(record.UUID)
+			if err != nil || seen[id] {
+				continue
+			}
+			seen[id] = true
+			disc := vwrest.DiscWorkflow{Uuid: id, Status: vwrest.DiscPhaseCompleted, Error: record.Error}
+			if record.Status == vwstore.StatusFailed {
+				disc.Status = vwrest.DiscPhaseFailed
+			}
+			discs = append(discs, disc)
+		}
+	}
+
+	return vwrest.ListDiscs200JSONResponse{
+		Body: vwrest.DiscList{Discs: discs},
+		Headers: vwrest.ListDiscs200ResponseHeaders{
 			CacheControl: "no-cache, no-store, must-revalidate",
 			Pragma:       "no-cache",
 			Expires:      "0",
@@ -269,10 +508,37 @@ func (s *Server) GetDisc(ctx context.Context, request vwrest.GetDiscRequestObjec
 	}, nil
 }
 
+// listStatus summarizes a workflow's execution status for ListDiscs, which does not query each disc for
+// its state.
+func listStatus(status enums.WorkflowExecutionStatus) vwrest.DiscPhase {
+	switch status {
+	case enums.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		return vwrest.DiscPhaseRunning
+	case enums.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		return vwrest.DiscPhaseCompleted
+	default:
+		return vwrest.DiscPhaseFailed
+	}
+}
+
+// queryState asks a disc workflow, running or closed, for its state.
+func (s *Server) queryState(ctx context.Context, workflowID, runID string) (vwdisc.State, error) {
+	resp, err := s.temporalClient.QueryWorkflow(ctx, workflowID, runID, vwdisc.QueryGetState)
+	if err != nil {
+		return vwdisc.State{}, fmt.Errorf("failed to query workflow: %w", err)
+	}
+	var state vwdisc.State
+	if err := resp.Get(&state); err != nil {
+		return vwdisc.State{}, fmt.Errorf("failed to decode workflow state: %w", err)
+	}
+	return state, nil
+}
+
 // GetInbox retrieves the list of disc paths in the inbox.
 func (s *Server) GetInbox(ctx context.Context, request vwrest.GetInboxRequestObject) (vwrest.GetInboxResponseObject, error) {
 	entries, err := os.ReadDir(s.config.InboxPath)
 	if err != nil {
+		slog.ErrorContext(ctx, "Failed to read inbox directory", "path", s.config.InboxPath, internal.LogKeyError, err)
 		return vwrest.GetInbox500JSONResponse{
 			Code:    "INTERNAL_ERROR",
 			Message: fmt.Sprintf("failed to read inbox directory: %v", err),
@@ -297,3 +563,11 @@ func (s *Server) GetInbox(ctx context.Context, request vwrest.GetInboxRequestObj
 		},
 	}, nil
 }
+
+// jobLogContext attaches the external job UUID from a webhook callback, if present, to ctx for logging.
+func jobLogContext(ctx context.Context, jobUUID *openapi_types.UUID) context.Context {
+	if jobUUID == nil {
+		return ctx
+	}
+	return internal.WithLogAttrs(ctx, internal.LogKeyJobUUID, jobUUID.String())
+}
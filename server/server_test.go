@@ -0,0 +1,469 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/vwactivity"
+	"github.com/krelinga/video-workflows/internal/vwstore"
+	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
+	"github.com/krelinga/video-workflows/vwrest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/temporal"
+)
+
+var testToken = []byte("task-token")
+
+type ServerTestSuite struct {
+	suite.Suite
+
+	client *mocks.Client
+	server *Server
+	ctx    context.Context
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.client = mocks.NewClient(s.T())
+	s.server = NewServer(s.client, "/nas/media/library", &internal.ServerConfig{TaskQueues: &internal.TaskQueueConfig{}}, nil)
+	s.ctx = context.Background()
+}
+
+func (s *ServerTestSuite) completeTranscode(id uuid.UUID, jobError *string) vwrest.CompleteTranscodeActivityResponseObject {
+	resp, err := s.server.CompleteTranscodeActivity(s.ctx, vwrest.CompleteTranscodeActivityRequestObject{
+		Body: &vwrest.CompleteTranscodeActivityRequest{Token: s.callbackToken(id), Uuid: &id, Error: jobError},
+	})
+	s.Require().NoError(err)
+	return resp
+}
+
+func (s *ServerTestSuite) completeGetVideoInfo(id uuid.UUID) vwrest.CompleteGetVideoInfoActivityResponseObject {
+	duration := 60.0
+	resp, err := s.server.CompleteGetVideoInfoActivity(s.ctx, vwrest.CompleteGetVideoInfoActivityRequestObject{
+		Body: &vwrest.CompleteGetVideoInfoActivityRequest{
+			Token:  s.callbackToken(id),
+			Uuid:   &id,
+			Result: &vwrest.CompleteGetVideoInfoActivityRequestResult{TotalDurationSeconds: &duration},
+		},
+	})
+	s.Require().NoError(err)
+	return resp
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_Duplicate() {
+	id := uuid.New()
+	s.client.On("CompleteActivity", mock.Anything, testToken, nil, nil).Return(nil).Once()
+
+	s.IsType(vwrest.CompleteTranscodeActivity200Response{}, s.completeTranscode(id, nil))
+	// The retried callback is answered without completing the activity again.
+	s.IsType(vwrest.CompleteTranscodeActivity200Response{}, s.completeTranscode(id, nil))
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_Gone() {
+	s.client.On("CompleteActivity", mock.Anything, testToken, nil, nil).
+		Return(serviceerror.NewNotFound("activity not found")).Once()
+
+	s.IsType(vwrest.CompleteTranscodeActivity410JSONResponse{}, s.completeTranscode(uuid.New(), nil))
+}
+
+// isJobFailure matches the error that a failed job's callback completes its activity with.  It must not
+// be retried, since a retry would reuse the job's UUID and lose jobError.
+func isJobFailure(jobError string) any {
+	return mock.MatchedBy(func(err error) bool {
+		var appErr *temporal.ApplicationError
+		return errors.As(err, &appErr) && appErr.NonRetryable() &&
+			appErr.Type() == vwactivity.ErrorTypeJobFailed && appErr.Message() == jobError
+	})
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_JobError() {
+	jobError := "corrupt file"
+	s.client.On("CompleteActivity", mock.Anything, testToken, nil, isJobFailure(jobError)).Return(nil).Once()
+
+	s.IsType(vwrest.CompleteTranscodeActivity200Response{}, s.completeTranscode(uuid.New(), &jobError))
+}
+
+func (s *ServerTestSuite) Test_CompleteGetVideoInfo_JobError() {
+	id := uuid.New()
+	jobError := "no video streams"
+	s.client.On("CompleteActivity", mock.Anything, testToken, nil, isJobFailure(jobError)).Return(nil).Once()
+
+	resp, err := s.server.CompleteGetVideoInfoActivity(s.ctx, vwrest.CompleteGetVideoInfoActivityRequestObject{
+		Body: &vwrest.CompleteGetVideoInfoActivityRequest{Token: s.callbackToken(id), Uuid: &id, Error: &jobError},
+	})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.CompleteGetVideoInfoActivity200Response{}, resp)
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_TemporalError() {
+	id := uuid.New()
+	s.client.On("CompleteActivity", mock.Anything, testToken, nil, nil).
+		Return(errors.New("unavailable")).Twice()
+
+	s.IsType(vwrest.CompleteTranscodeActivity500JSONResponse{}, s.completeTranscode(id, nil))
+	// A failed completion is not recorded, so the retry reaches Temporal again.
+	s.IsType(vwrest.CompleteTranscodeActivity500JSONResponse{}, s.completeTranscode(id, nil))
+}
+
+func (s *ServerTestSuite) Test_CompleteGetVideoInfo_Duplicate() {
+	id := uuid.New()
+	s.client.On("CompleteActivity", mock.Anything, testToken, vwactivity.VideoInfo{DurationSeconds: 60}, nil).
+		Return(nil).Once()
+
+	s.IsType(vwrest.CompleteGetVideoInfoActivity200Response{}, s.completeGetVideoInfo(id))
+	s.IsType(vwrest.CompleteGetVideoInfoActivity200Response{}, s.completeGetVideoInfo(id))
+}
+
+func (s *ServerTestSuite) Test_CompleteGetVideoInfo_Gone() {
+	s.client.On("CompleteActivity", mock.Anything, testToken, mock.Anything, nil).
+		Return(serviceerror.NewNotFound("workflow execution already completed")).Once()
+
+	s.IsType(vwrest.CompleteGetVideoInfoActivity410JSONResponse{}, s.completeGetVideoInfo(uuid.New()))
+}
+
+func (s *ServerTestSuite) Test_TranscodeHeartbeat_Gone() {
+	id := uuid.New()
+	s.client.On("RecordActivityHeartbeat", mock.Anything, testToken, mock.Anything).
+		Return(serviceerror.NewNotFound("activity not found")).Once()
+
+	resp, err := s.server.TranscodeActivityHeartbeat(s.ctx, vwrest.TranscodeActivityHeartbeatRequestObject{
+		Body: &vwrest.HeartbeatTranscodeActivityRequest{Token: s.callbackToken(id), Uuid: &id, Progress: 50},
+	})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.TranscodeActivityHeartbeat410JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) callbackToken(jobUUID uuid.UUID) []byte {
+	token, err := json.Marshal(vwactivity.CallbackToken{JobUUID: jobUUID.String(), TaskToken: testToken})
+	s.Require().NoError(err)
+	return token
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_CallbackToken() {
+	id := uuid.New()
+	s.client.On("CompleteActivity", mock.Anything, testToken, nil, nil).Return(nil).Once()
+
+	resp, err := s.server.CompleteTranscodeActivity(s.ctx, vwrest.CompleteTranscodeActivityRequestObject{
+		Body: &vwrest.CompleteTranscodeActivityRequest{Token: s.callbackToken(id), Uuid: &id},
+	})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.CompleteTranscodeActivity200Response{}, resp)
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_BareToken() {
+	id := uuid.New()
+
+	resp, err := s.server.CompleteTranscodeActivity(s.ctx, vwrest.CompleteTranscodeActivityRequestObject{
+		Body: &vwrest.CompleteTranscodeActivityRequest{Token: testToken, Uuid: &id},
+	})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.CompleteTranscodeActivity400JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_BareTokenAllowed() {
+	s.server.config.AllowBareCallbackTokens = true
+	id := uuid.New()
+	s.client.On("CompleteActivity", mock.Anything, testToken, nil, nil).Return(nil).Once()
+
+	resp, err := s.server.CompleteTranscodeActivity(s.ctx, vwrest.CompleteTranscodeActivityRequestObject{
+		Body: &vwrest.CompleteTranscodeActivityRequest{Token: testToken, Uuid: &id},
+	})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.CompleteTranscodeActivity200Response{}, resp)
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_ByID() {
+	id := uuid.New()
+	token, err := json.Marshal(vwactivity.CallbackToken{
+		JobUUID:    id.String(),
+		Namespace:  "default",
+		WorkflowID: "disc-uuid",
+		RunID:      "run-id",
+		ActivityID: "7",
+	})
+	s.Require().NoError(err)
+	s.client.On("CompleteActivityByID", mock.Anything, "default", "disc-uuid", "run-id", "7", nil, nil).Return(nil).Once()
+	s.client.On("RecordActivityHeartbeatByID", mock.Anything, "default", "disc-uuid", "run-id", "7", mock.Anything).Return(nil).Once()
+
+	heartbeat, err := s.server.TranscodeActivityHeartbeat(s.ctx, vwrest.TranscodeActivityHeartbeatRequestObject{
+		Body: &vwrest.HeartbeatTranscodeActivityRequest{Token: token, Uuid: &id, Progress: 50},
+	})
+	s.Require().NoError(err)
+	s.IsType(vwrest.TranscodeActivityHeartbeat200Response{}, heartbeat)
+
+	resp, err := s.server.CompleteTranscodeActivity(s.ctx, vwrest.CompleteTranscodeActivityRequestObject{
+		Body: &vwrest.CompleteTranscodeActivityRequest{Token: token, Uuid: &id},
+	})
+	s.Require().NoError(err)
+	s.IsType(vwrest.CompleteTranscodeActivity200Response{}, resp)
+}
+
+// useLogger sends the default logger's records to the returned buffer until the test ends.
+func (s *ServerTestSuite) useLogger() *bytes.Buffer {
+	previous := slog.Default()
+	s.T().Cleanup(func() { slog.SetDefault(previous) })
+	var out bytes.Buffer
+	slog.SetDefault(internal.NewLogger(&internal.LoggingConfig{}, &out))
+	return &out
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_LogsDisc() {
+	tests := []struct {
+		name  string
+		token vwactivity.CallbackToken
+		mock  func()
+	}{
+		{
+			name:  "task token",
+			token: vwactivity.CallbackToken{DiscUUID: "disc-uuid", TaskToken: testToken},
+			mock: func() {
+				s.client.On("CompleteActivity", mock.Anything, testToken, nil, nil).Return(nil).Once()
+			},
+		},
+		{
+			// Tokens issued before they carried the disc UUID name it as the workflow ID in ID mode.
+			name:  "by ID without disc UUID",
+			token: vwactivity.CallbackToken{Namespace: "default", WorkflowID: "disc-uuid", ActivityID: "7"},
+			mock: func() {
+				s.client.On("CompleteActivityByID", mock.Anything, "default", "disc-uuid", "", "7", nil, nil).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			logs := s.useLogger()
+			id := uuid.New()
+			tt.token.JobUUID = id.String()
+			token, err := json.Marshal(tt.token)
+			s.Require().NoError(err)
+			tt.mock()
+
+			_, err = s.server.CompleteTranscodeActivity(s.ctx, vwrest.CompleteTranscodeActivityRequestObject{
+				Body: &vwrest.CompleteTranscodeActivityRequest{Token: token, Uuid: &id},
+			})
+			s.Require().NoError(err)
+
+			var received map[string]any
+			for line := range bytes.Lines(logs.Bytes()) {
+				var record map[string]any
+				s.Require().NoError(json.Unmarshal(line, &record))
+				if record["msg"] == "Received Transcode completion" {
+					received = record
+				}
+			}
+			s.Require().NotNil(received, "expected the callback to be logged, got %s", logs)
+			s.Equal("disc-uuid", received[internal.LogKeyDiscUUID])
+			s.Equal("disc-uuid", received[internal.LogKeyWorkflowID])
+			s.Equal(id.String(), received[internal.LogKeyJobUUID])
+		})
+	}
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_UUIDMismatch() {
+	id, other := uuid.New(), uuid.New()
+
+	resp, err := s.server.CompleteTranscodeActivity(s.ctx, vwrest.CompleteTranscodeActivityRequestObject{
+		Body: &vwrest.CompleteTranscodeActivityRequest{Token: s.callbackToken(id), Uuid: &other},
+	})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.CompleteTranscodeActivity400JSONResponse{}, resp)
+	s.client.AssertNotCalled(s.T(), "CompleteActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ServerTestSuite) Test_CompleteGetVideoInfo_MissingUUID() {
+	resp, err := s.server.CompleteGetVideoInfoActivity(s.ctx, vwrest.CompleteGetVideoInfoActivityRequestObject{
+		Body: &vwrest.CompleteGetVideoInfoActivityRequest{
+			Token:  s.callbackToken(uuid.New()),
+			Result: &vwrest.CompleteGetVideoInfoActivityRequestResult{},
+		},
+	})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.CompleteGetVideoInfoActivity400JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_TranscodeHeartbeat_UUIDMismatch() {
+	id, other := uuid.New(), uuid.New()
+
+	resp, err := s.server.TranscodeActivityHeartbeat(s.ctx, vwrest.TranscodeActivityHeartbeatRequestObject{
+		Body: &vwrest.HeartbeatTranscodeActivityRequest{Token: s.callbackToken(id), Uuid: &other, Progress: 50},
+	})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.TranscodeActivityHeartbeat400JSONResponse{}, resp)
+}
+
+func TestCallbackLog(t *testing.T) {
+	log := newCallbackLog(2)
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+	log.Record(callbackTranscode, &first)
+	if !log.Contains(callbackTranscode, &first) {
+		t.Errorf("expected %s to be recorded", first)
+	}
+	if log.Contains(callbackGetVideoInfo, &first) {
+		t.Errorf("expected kinds to be kept apart")
+	}
+	if log.Contains(callbackTranscode, nil) {
+		t.Errorf("expected callbacks without a UUID never to be duplicates")
+	}
+
+	log.Record(callbackTranscode, &second)
+	log.Record(callbackTranscode, &third)
+	if log.Contains(callbackTranscode, &first) {
+		t.Errorf("expected %s to be evicted", first)
+	}
+	if !log.Contains(callbackTranscode, &second) || !log.Contains(callbackTranscode, &third) {
+		t.Errorf("expected the newest callbacks to be kept")
+	}
+}
+
+func (s *ServerTestSuite) describeDisc(id uuid.UUID, status enums.WorkflowExecutionStatus) {
+	s.client.On("DescribeWorkflowExecution", mock.Anything, id.String(), "").Return(&workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{Status: status, FirstRunId: "run-id"},
+	}, nil).Once()
+}
+
+func (s *ServerTestSuite) endedRun(err error) {
+	run := mocks.NewWorkflowRun(s.T())
+	run.On("Get", mock.Anything, nil).Return(err).Once()
+	s.client.On("GetWorkflow", mock.Anything, mock.Anything, "run-id").Return(run).Once()
+}
+
+func (s *ServerTestSuite) Test_GetDisc_FailedKeepsState() {
+	id := uuid.New()
+	duration := 60.0
+	listedAt := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	state := vwdisc.State{
+		Phase:          vwdisc.PhaseFilesListed,
+		PhaseEnteredAt: map[vwdisc.Phase]time.Time{vwdisc.PhaseFilesListed: listedAt},
+		Files:          map[string]vwdisc.FileState{"/nas/media/library/disc/title.mkv": {DurationSeconds: &duration}},
+	}
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_FAILED)
+	s.endedRun(temporal.NewApplicationErrorWithCause("failed to create preview directory", vwdisc.ErrorTypeDiscFailed, errors.New("disk full"), state))
+
+	resp, err := s.server.GetDisc(s.ctx, vwrest.GetDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.Require().IsType(vwrest.GetDisc200JSONResponse{}, resp)
+	body := resp.(vwrest.GetDisc200JSONResponse).Body
+	s.Equal(vwrest.DiscPhaseFailed, body.Status)
+	s.Equal(listedAt, body.PhaseEnteredAt[string(vwdisc.PhaseFilesListed)])
+	s.Contains(body.PhaseEnteredAt, string(vwrest.DiscPhaseFailed))
+	s.Require().NotNil(body.Error)
+	s.Contains(*body.Error, "failed to create preview directory")
+	s.Require().Len(body.Files, 1)
+	s.Equal("/nas/media/library/disc/title.mkv", body.Files[0].Filename)
+	s.Equal(&duration, body.Files[0].DurationSeconds)
+}
+
+func (s *ServerTestSuite) Test_GetDisc_TerminatedQueriesState() {
+	id := uuid.New()
+	s.describeDisc(id, enums.WORKFLOW_EXECUTION_STATUS_TERMINATED)
+	s.endedRun(errors.New("workflow terminated"))
+	s.client.On("QueryWorkflow", mock.Anything, id.String(), "run-id", vwdisc.QueryGetState).
+		Return(nil, errors.New("no worker available")).Once()
+
+	resp, err := s.server.GetDisc(s.ctx, vwrest.GetDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.Require().IsType(vwrest.GetDisc200JSONResponse{}, resp)
+	body := resp.(vwrest.GetDisc200JSONResponse).Body
+	s.Equal(vwrest.DiscPhaseFailed, body.Status)
+	s.Empty(body.Files)
+}
+
+// useStore gives the server a disc store holding records.
+func (s *ServerTestSuite) useStore(records ...vwstore.Record) {
+	store, err := vwstore.Open(filepath.Join(s.T().TempDir(), "discs.db"))
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { store.Close() })
+	for _, record := range records {
+		s.Require().NoError(store.Put(s.ctx, record))
+	}
+	s.server = NewServer(s.client, "/nas/media/library", &internal.ServerConfig{TaskQueues: &internal.TaskQueueConfig{}}, store)
+}
+
+func (s *ServerTestSuite) Test_GetDisc_PurgedFromTemporal() {
+	id := uuid.New()
+	s.useStore(vwstore.Record{
+		UUID:     id.String(),
+		Status:   vwstore.StatusCompleted,
+		State:    json.RawMessage(`{"phase":"got_file_diagnostics","phase_entered_at":{"got_file_diagnostics":"2026-01-02T15:04:05Z"},"files":{"/nas/media/library/disc/title.mkv":{}}}`),
+		ClosedAt: time.Now(),
+	})
+	s.client.On("DescribeWorkflowExecution", mock.Anything, id.String(), "").
+		Return(nil, serviceerror.NewNotFound("workflow not found")).Once()
+
+	resp, err := s.server.GetDisc(s.ctx, vwrest.GetDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.Require().IsType(vwrest.GetDisc200JSONResponse{}, resp)
+	body := resp.(vwrest.GetDisc200JSONResponse).Body
+	s.Equal(vwrest.DiscPhaseGotFileDiagnostics, body.Status)
+	s.Equal(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), body.PhaseEnteredAt[string(vwdisc.PhaseGotFileDiagnostics)])
+	s.Require().Len(body.Files, 1)
+	s.Equal("/nas/media/library/disc/title.mkv", body.Files[0].Filename)
+}
+
+func (s *ServerTestSuite) Test_GetDisc_NotFoundAnywhere() {
+	id := uuid.New()
+	s.useStore()
+	s.client.On("DescribeWorkflowExecution", mock.Anything, id.String(), "").
+		Return(nil, serviceerror.NewNotFound("workflow not found")).Once()
+
+	resp, err := s.server.GetDisc(s.ctx, vwrest.GetDiscRequestObject{Uuid: id})
+
+	s.Require().NoError(err)
+	s.IsType(vwrest.GetDisc404JSONResponse{}, resp)
+}
+
+func (s *ServerTestSuite) Test_ListDiscs() {
+	running, stored, purged := uuid.New(), uuid.New(), uuid.New()
+	errorMessage := "failed to move directory"
+	s.useStore(
+		vwstore.Record{UUID: stored.String(), Status: vwstore.StatusCompleted, State: json.RawMessage(`{}`), ClosedAt: time.Now()},
+		vwstore.Record{UUID: purged.String(), Status: vwstore.StatusFailed, Error: &errorMessage, State: json.RawMessage(`{}`), ClosedAt: time.Now()},
+	)
+	s.client.On("ListWorkflow", mock.Anything, mock.Anything).Return(&workflowservice.ListWorkflowExecutionsResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{
+			{Execution: &commonpb.WorkflowExecution{WorkflowId: running.String()}, Status: enums.WORKFLOW_EXECUTION_STATUS_RUNNING},
+			{Execution: &commonpb.WorkflowExecution{WorkflowId: stored.String()}, Status: enums.WORKFLOW_EXECUTION_STATUS_COMPLETED},
+		},
+	}, nil).Once()
+
+	resp, err := s.server.ListDiscs(s.ctx, vwrest.ListDiscsRequestObject{})
+
+	s.Require().NoError(err)
+	s.Require().IsType(vwrest.ListDiscs200JSONResponse{}, resp)
+	s.Equal([]vwrest.DiscWorkflow{
+		{Uuid: running, Status: vwrest.DiscPhaseRunning},
+		{Uuid: stored, Status: vwrest.DiscPhaseCompleted},
+		{Uuid: purged, Status: vwrest.DiscPhaseFailed, Error: &errorMessage},
+	}, resp.(vwrest.ListDiscs200JSONResponse).Body.Discs)
+}
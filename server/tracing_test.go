@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-transcoder/vtrest"
+	"github.com/krelinga/video-workflows/internal"
+	"github.com/krelinga/video-workflows/internal/vwactivity"
+	"github.com/krelinga/video-workflows/vwrest"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// exportedSpan is the part of a span written by the stdout exporter that the tests look at.
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Links []struct {
+		SpanContext struct {
+			SpanID string
+		}
+	}
+}
+
+// useStdoutTracing installs a tracer provider that exports spans to the returned buffer, restoring the
+// previous globals when the test ends.
+func (s *ServerTestSuite) useStdoutTracing() *bytes.Buffer {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	s.T().Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	var spans bytes.Buffer
+	tracerProvider, err := internal.NewTracerProvider(s.ctx, &internal.TracingConfig{
+		Exporter: internal.TracingExporterStdout,
+		Writer:   &spans,
+	}, "test")
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { tracerProvider.Shutdown(context.Background()) })
+	return &spans
+}
+
+// exportedSpans decodes the spans written by the stdout exporter.
+func (s *ServerTestSuite) exportedSpans(r io.Reader) []exportedSpan {
+	var spans []exportedSpan
+	decoder := json.NewDecoder(r)
+	for {
+		var span exportedSpan
+		err := decoder.Decode(&span)
+		if errors.Is(err, io.EOF) {
+			return spans
+		}
+		s.Require().NoError(err)
+		spans = append(spans, span)
+	}
+}
+
+// capturingTranscodeClient accepts transcode jobs, keeping the webhook token of the last one.
+type capturingTranscodeClient struct {
+	vtrest.ClientWithResponsesInterface
+
+	token []byte
+}
+
+func (c *capturingTranscodeClient) CreateTranscodeWithResponse(ctx context.Context, body vtrest.CreateTranscodeJSONRequestBody, reqEditors ...vtrest.RequestEditorFn) (*vtrest.CreateTranscodeResponse, error) {
+	c.token = body.WebhookToken
+	return &vtrest.CreateTranscodeResponse{JSON201: &vtrest.TranscodeJob{}}, nil
+}
+
+func (s *ServerTestSuite) Test_CompleteTranscode_ContinuesActivityTrace() {
+	spans := s.useStdoutTracing()
+	tracingInterceptor, err := internal.NewTracingInterceptor()
+	s.Require().NoError(err)
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{Interceptors: []interceptor.WorkerInterceptor{tracingInterceptor}})
+	transcoder := &capturingTranscodeClient{}
+	deps := &vwactivity.TranscodeDeps{Client: transcoder}
+	env.RegisterActivity(deps)
+	jobUUID := uuid.New()
+
+	// The transcoder calls back while the activity is waiting for it, as part of a request with a trace of
+	// its own.
+	s.client.On("CompleteActivity", mock.Anything, mock.Anything, nil, nil).Run(func(args mock.Arguments) {
+		env.CompleteActivity(args.Get(1).([]byte), nil, nil)
+	}).Return(nil).Once()
+	var requestSpanID string
+	env.RegisterDelayedCallback(func() {
+		ctx, requestSpan := otel.Tracer("test").Start(s.ctx, "request")
+		defer requestSpan.End()
+		requestSpanID = requestSpan.SpanContext().SpanID().String()
+		resp, err := s.server.CompleteTranscodeActivity(ctx, vwrest.CompleteTranscodeActivityRequestObject{
+			Body: &vwrest.CompleteTranscodeActivityRequest{Token: transcoder.token, Uuid: &jobUUID},
+		})
+		s.Require().NoError(err)
+		s.IsType(vwrest.CompleteTranscodeActivity200Response{}, resp)
+	}, time.Minute)
+	env.ExecuteWorkflow(func(ctx workflow.Context) error {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout: time.Hour,
+			RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 1},
+		})
+		return workflow.ExecuteActivity(ctx, deps.Transcode, vwactivity.TranscodeParams{Uuid: jobUUID.String()}).Get(ctx, nil)
+	})
+	s.Require().True(env.IsWorkflowCompleted())
+	s.Require().NoError(env.GetWorkflowError())
+
+	var workflowTraceID string
+	var callback *exportedSpan
+	for _, span := range s.exportedSpans(spans) {
+		switch {
+		case strings.HasPrefix(span.Name, "RunWorkflow"):
+			workflowTraceID = span.SpanContext.TraceID
+		case span.Name == "CompleteActivity":
+			callback = &span
+		}
+	}
+	s.Require().NotEmpty(workflowTraceID)
+	s.Require().NotNil(callback)
+	s.Equal(workflowTraceID, callback.SpanContext.TraceID)
+	s.Require().Len(callback.Links, 1)
+	s.Equal(requestSpanID, callback.Links[0].SpanContext.SpanID)
+}
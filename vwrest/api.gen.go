@@ -17,6 +17,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
@@ -24,6 +25,33 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for DiscHistoryEntryKind.
+const (
+	DiscHistoryEntryKindActivity DiscHistoryEntryKind = "activity"
+	DiscHistoryEntryKindSignal   DiscHistoryEntryKind = "signal"
+	DiscHistoryEntryKindUpdate   DiscHistoryEntryKind = "update"
+	DiscHistoryEntryKindWorkflow DiscHistoryEntryKind = "workflow"
+)
+
+// Defines values for DiscPhase.
+const (
+	DiscPhaseCompleted          DiscPhase = "completed"
+	DiscPhaseCreated            DiscPhase = "created"
+	DiscPhaseDirectoryMoved     DiscPhase = "directory_moved"
+	DiscPhaseFailed             DiscPhase = "failed"
+	DiscPhaseFilesListed        DiscPhase = "files_listed"
+	DiscPhaseGotFileDiagnostics DiscPhase = "got_file_diagnostics"
+	DiscPhasePaused             DiscPhase = "paused"
+	DiscPhaseRunning            DiscPhase = "running"
+)
+
+// Defines values for ReprocessDiscRequestStage.
+const (
+	ReprocessDiscRequestStageDiagnostics    ReprocessDiscRequestStage = "diagnostics"
+	ReprocessDiscRequestStageFinalTranscode ReprocessDiscRequestStage = "final_transcode"
+	ReprocessDiscRequestStageListFiles      ReprocessDiscRequestStage = "list_files"
+)
+
 // CompleteGetVideoInfoActivityRequest defines model for CompleteGetVideoInfoActivityRequest.
 type CompleteGetVideoInfoActivityRequest struct {
 	// Error Error message if the activity failed
@@ -33,7 +61,7 @@ type CompleteGetVideoInfoActivityRequest struct {
 	// Token Base64-encoded binary token
 	Token []byte `json:"token"`
 
-	// Uuid UUID that was used to start the GetVideoInfo activity.
+	// Uuid UUID that was used to start the GetVideoInfo activity.  Must match the job the token was issued for.
 	Uuid *openapi_types.UUID `json:"uuid,omitempty"`
 }
 
@@ -54,7 +82,7 @@ type CompleteTranscodeActivityRequest struct {
 	// Token Base64-encoded binary token
 	Token []byte `json:"token"`
 
-	// Uuid UUID that was used to start the transcode.
+	// Uuid UUID that was used to start the transcode.  Must match the job the token was issued for.
 	Uuid *openapi_types.UUID `json:"uuid,omitempty"`
 }
 
@@ -67,14 +95,85 @@ type CreateDiscRequest struct {
 	Uuid openapi_types.UUID `json:"uuid"`
 }
 
+// DiscHistory defines model for DiscHistory.
+type DiscHistory struct {
+	// Entries Timeline entries in the order they began.
+	Entries []DiscHistoryEntry `json:"entries"`
+	Uuid    openapi_types.UUID `json:"uuid"`
+}
+
+// DiscHistoryEntry defines model for DiscHistoryEntry.
+type DiscHistoryEntry struct {
+	// Attempt The activity attempt that started last.
+	Attempt *int `json:"attempt,omitempty"`
+
+	// ClosedAt When the entry finished.
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+
+	// DurationSeconds Time from scheduledAt to closedAt, including any retries.
+	DurationSeconds *float64 `json:"durationSeconds,omitempty"`
+
+	// Error Failure message, if the entry failed.
+	Error *string `json:"error,omitempty"`
+
+	// File The file or directory the activity worked on, if any.
+	File *string `json:"file,omitempty"`
+
+	// Kind What the entry describes.
+	Kind DiscHistoryEntryKind `json:"kind"`
+
+	// Name Activity type, signal name or update name.  For workflow entries, the workflow event:
+	// started, completed, failed, timedOut, canceled, terminated or continuedAsNew.
+	Name string `json:"name"`
+
+	// ScheduledAt When the entry began; for activities, when the first attempt was scheduled.
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+
+	// Stage The workflow stage that issued the activity.  Absent for discs started before stages were recorded.
+	Stage *string `json:"stage,omitempty"`
+
+	// StartedAt When the last attempt of an activity started.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	// Status scheduled, running, completed, failed, timedOut or canceled for activities and updates;
+	// received for signals; the same as name for workflow entries.
+	Status string `json:"status"`
+}
+
+// DiscHistoryEntryKind What the entry describes.
+type DiscHistoryEntryKind string
+
+// DiscList defines model for DiscList.
+type DiscList struct {
+	// Discs Disc workflows, without their files
+	Discs []DiscWorkflow `json:"discs"`
+}
+
+// DiscPhase How far a disc has got.  created is only returned by createDisc, and completed only by
+// listDiscs, which does not read each disc's state; getDisc reports the last phase a completed
+// disc reached instead.  paused is reported by getDisc between pauseDisc and resumeDisc, in
+// place of the phase the disc is in.
+type DiscPhase string
+
 // DiscWorkflow defines model for DiscWorkflow.
 type DiscWorkflow struct {
 	// Error Error message if the workflow failed
 	Error *string `json:"error,omitempty"`
 
-	// Files List of files being processed by the disc workflow.
-	Files  []DiscWorkflowFile `json:"files,omitempty"`
-	Status string             `json:"status"`
+	// Files List of files being processed by the disc workflow, ordered by title number and then by
+	// name.  For a failed workflow, these are the files and results it had collected before the
+	// failure.
+	Files []DiscWorkflowFile `json:"files,omitempty"`
+
+	// PhaseEnteredAt When the disc entered each phase it has reached, keyed by phase.  For a failed disc, failed
+	// is the time the workflow ended.  Not included by listDiscs.
+	PhaseEnteredAt map[string]time.Time `json:"phaseEnteredAt,omitempty"`
+
+	// Status How far a disc has got.  created is only returned by createDisc, and completed only by
+	// listDiscs, which does not read each disc's state; getDisc reports the last phase a completed
+	// disc reached instead.  paused is reported by getDisc between pauseDisc and resumeDisc, in
+	// place of the phase the disc is in.
+	Status DiscPhase          `json:"status"`
 	Uuid   openapi_types.UUID `json:"uuid"`
 }
 
@@ -85,10 +184,24 @@ type DiscWorkflowFile struct {
 
 	// DurationSeconds Duration of the video file in seconds.
 	DurationSeconds *float64 `json:"durationSeconds,omitempty"`
-	Filename        string   `json:"filename"`
+
+	// Filename Absolute path of the video file.
+	Filename string `json:"filename"`
+
+	// Id Stable identifier for the file within its disc, which does not change between requests.
+	Id string `json:"id"`
+
+	// Name Base name of the video file.
+	Name string `json:"name"`
 
 	// PreviewPath Path to the generated preview video for the video file.
 	PreviewPath *string `json:"previewPath,omitempty"`
+
+	// RelativePath Path of the video file relative to the disc's directory.
+	RelativePath string `json:"relativePath"`
+
+	// SizeBytes Size of the video file in bytes.
+	SizeBytes *int64 `json:"sizeBytes,omitempty"`
 }
 
 // Error defines model for Error.
@@ -105,7 +218,7 @@ type HeartbeatTranscodeActivityRequest struct {
 	// Token Base64-encoded binary token
 	Token []byte `json:"token"`
 
-	// Uuid UUID that was used to start the transcode.
+	// Uuid UUID that was used to start the transcode.  Must match the job the token was issued for.
 	Uuid *openapi_types.UUID `json:"uuid,omitempty"`
 }
 
@@ -115,6 +228,21 @@ type InboxResponse struct {
 	Paths []string `json:"paths"`
 }
 
+// ReprocessDiscRequest defines model for ReprocessDiscRequest.
+type ReprocessDiscRequest struct {
+	// Stage Stage to reprocess the disc from.  list_files lists the disc's files again and then gets
+	// their diagnostics; diagnostics keeps the listed files and regenerates their video info and
+	// previews; final_transcode transcodes the main title again.  Disc workflows do not transcode
+	// the main title yet, so final_transcode is always refused with a 409 for now.
+	Stage ReprocessDiscRequestStage `json:"stage"`
+}
+
+// ReprocessDiscRequestStage Stage to reprocess the disc from.  list_files lists the disc's files again and then gets
+// their diagnostics; diagnostics keeps the listed files and regenerates their video info and
+// previews; final_transcode transcodes the main title again.  Disc workflows do not transcode
+// the main title yet, so final_transcode is always refused with a 409 for now.
+type ReprocessDiscRequestStage string
+
 // CompleteGetVideoInfoActivityJSONRequestBody defines body for CompleteGetVideoInfoActivity for application/json ContentType.
 type CompleteGetVideoInfoActivityJSONRequestBody = CompleteGetVideoInfoActivityRequest
 
@@ -127,6 +255,9 @@ type TranscodeActivityHeartbeatJSONRequestBody = HeartbeatTranscodeActivityReque
 // CreateDiscJSONRequestBody defines body for CreateDisc for application/json ContentType.
 type CreateDiscJSONRequestBody = CreateDiscRequest
 
+// ReprocessDiscJSONRequestBody defines body for ReprocessDisc for application/json ContentType.
+type ReprocessDiscJSONRequestBody = ReprocessDiscRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -215,6 +346,9 @@ type ClientInterface interface {
 
 	TranscodeActivityHeartbeat(ctx context.Context, body TranscodeActivityHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListDiscs request
+	ListDiscs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// CreateDiscWithBody request with any body
 	CreateDiscWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -223,6 +357,20 @@ type ClientInterface interface {
 	// GetDisc request
 	GetDisc(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetDiscHistory request
+	GetDiscHistory(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PauseDisc request
+	PauseDisc(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReprocessDiscWithBody request with any body
+	ReprocessDiscWithBody(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReprocessDisc(ctx context.Context, uuid openapi_types.UUID, body ReprocessDiscJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResumeDisc request
+	ResumeDisc(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetInbox request
 	GetInbox(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
@@ -299,6 +447,18 @@ func (c *Client) TranscodeActivityHeartbeat(ctx context.Context, body TranscodeA
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListDiscs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDiscsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) CreateDiscWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewCreateDiscRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -335,6 +495,66 @@ func (c *Client) GetDisc(ctx context.Context, uuid openapi_types.UUID, reqEditor
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetDiscHistory(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDiscHistoryRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PauseDisc(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPauseDiscRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReprocessDiscWithBody(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprocessDiscRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReprocessDisc(ctx context.Context, uuid openapi_types.UUID, body ReprocessDiscJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprocessDiscRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResumeDisc(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResumeDiscRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetInbox(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetInboxRequest(c.Server)
 	if err != nil {
@@ -467,6 +687,33 @@ func NewTranscodeActivityHeartbeatRequestWithBody(server string, contentType str
 	return req, nil
 }
 
+// NewListDiscsRequest generates requests for ListDiscs
+func NewListDiscsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/disc")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewCreateDiscRequest calls the generic CreateDisc builder with application/json body
 func NewCreateDiscRequest(server string, body CreateDiscJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -541,16 +788,23 @@ func NewGetDiscRequest(server string, uuid openapi_types.UUID) (*http.Request, e
 	return req, nil
 }
 
-// NewGetInboxRequest generates requests for GetInbox
-func NewGetInboxRequest(server string) (*http.Request, error) {
+// NewGetDiscHistoryRequest generates requests for GetDiscHistory
+func NewGetDiscHistoryRequest(server string, uuid openapi_types.UUID) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/inbox")
+	operationPath := fmt.Sprintf("/disc/%s/history", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -568,94 +822,254 @@ func NewGetInboxRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewPauseDiscRequest generates requests for PauseDisc
+func NewPauseDiscRequest(server string, uuid openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	operationPath := fmt.Sprintf("/disc/%s/pause", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
+
+	return req, nil
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// CompleteGetVideoInfoActivityWithBodyWithResponse request with any body
-	CompleteGetVideoInfoActivityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CompleteGetVideoInfoActivityResponse, error)
+// NewReprocessDiscRequest calls the generic ReprocessDisc builder with application/json body
+func NewReprocessDiscRequest(server string, uuid openapi_types.UUID, body ReprocessDiscJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReprocessDiscRequestWithBody(server, uuid, "application/json", bodyReader)
+}
 
-	CompleteGetVideoInfoActivityWithResponse(ctx context.Context, body CompleteGetVideoInfoActivityJSONRequestBody, reqEditors ...RequestEditorFn) (*CompleteGetVideoInfoActivityResponse, error)
+// NewReprocessDiscRequestWithBody generates requests for ReprocessDisc with any type of body
+func NewReprocessDiscRequestWithBody(server string, uuid openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	// CompleteTranscodeActivityWithBodyWithResponse request with any body
-	CompleteTranscodeActivityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CompleteTranscodeActivityResponse, error)
+	var pathParam0 string
 
-	CompleteTranscodeActivityWithResponse(ctx context.Context, body CompleteTranscodeActivityJSONRequestBody, reqEditors ...RequestEditorFn) (*CompleteTranscodeActivityResponse, error)
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
 
-	// TranscodeActivityHeartbeatWithBodyWithResponse request with any body
-	TranscodeActivityHeartbeatWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TranscodeActivityHeartbeatResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	TranscodeActivityHeartbeatWithResponse(ctx context.Context, body TranscodeActivityHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*TranscodeActivityHeartbeatResponse, error)
+	operationPath := fmt.Sprintf("/disc/%s/reprocess", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// CreateDiscWithBodyWithResponse request with any body
-	CreateDiscWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDiscResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	CreateDiscWithResponse(ctx context.Context, body CreateDiscJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDiscResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetDiscWithResponse request
-	GetDiscWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetDiscResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	// GetInboxWithResponse request
-	GetInboxWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInboxResponse, error)
+	return req, nil
 }
 
-type CompleteGetVideoInfoActivityResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON500      *Error
-}
+// NewResumeDiscRequest generates requests for ResumeDisc
+func NewResumeDiscRequest(server string, uuid openapi_types.UUID) (*http.Request, error) {
+	var err error
 
-// Status returns HTTPResponse.Status
-func (r CompleteGetVideoInfoActivityResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CompleteGetVideoInfoActivityResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/disc/%s/resume", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInboxRequest generates requests for GetInbox
+func NewGetInboxRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/inbox")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// CompleteGetVideoInfoActivityWithBodyWithResponse request with any body
+	CompleteGetVideoInfoActivityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CompleteGetVideoInfoActivityResponse, error)
+
+	CompleteGetVideoInfoActivityWithResponse(ctx context.Context, body CompleteGetVideoInfoActivityJSONRequestBody, reqEditors ...RequestEditorFn) (*CompleteGetVideoInfoActivityResponse, error)
+
+	// CompleteTranscodeActivityWithBodyWithResponse request with any body
+	CompleteTranscodeActivityWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CompleteTranscodeActivityResponse, error)
+
+	CompleteTranscodeActivityWithResponse(ctx context.Context, body CompleteTranscodeActivityJSONRequestBody, reqEditors ...RequestEditorFn) (*CompleteTranscodeActivityResponse, error)
+
+	// TranscodeActivityHeartbeatWithBodyWithResponse request with any body
+	TranscodeActivityHeartbeatWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TranscodeActivityHeartbeatResponse, error)
+
+	TranscodeActivityHeartbeatWithResponse(ctx context.Context, body TranscodeActivityHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*TranscodeActivityHeartbeatResponse, error)
+
+	// ListDiscsWithResponse request
+	ListDiscsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDiscsResponse, error)
+
+	// CreateDiscWithBodyWithResponse request with any body
+	CreateDiscWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDiscResponse, error)
+
+	CreateDiscWithResponse(ctx context.Context, body CreateDiscJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateDiscResponse, error)
+
+	// GetDiscWithResponse request
+	GetDiscWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetDiscResponse, error)
+
+	// GetDiscHistoryWithResponse request
+	GetDiscHistoryWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetDiscHistoryResponse, error)
+
+	// PauseDiscWithResponse request
+	PauseDiscWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*PauseDiscResponse, error)
+
+	// ReprocessDiscWithBodyWithResponse request with any body
+	ReprocessDiscWithBodyWithResponse(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReprocessDiscResponse, error)
+
+	ReprocessDiscWithResponse(ctx context.Context, uuid openapi_types.UUID, body ReprocessDiscJSONRequestBody, reqEditors ...RequestEditorFn) (*ReprocessDiscResponse, error)
+
+	// ResumeDiscWithResponse request
+	ResumeDiscWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*ResumeDiscResponse, error)
+
+	// GetInboxWithResponse request
+	GetInboxWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInboxResponse, error)
+}
+
+type CompleteGetVideoInfoActivityResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON410      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CompleteGetVideoInfoActivityResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CompleteGetVideoInfoActivityResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
@@ -665,6 +1079,7 @@ type CompleteTranscodeActivityResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON400      *Error
+	JSON410      *Error
 	JSON500      *Error
 }
 
@@ -688,6 +1103,7 @@ type TranscodeActivityHeartbeatResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON400      *Error
+	JSON410      *Error
 	JSON500      *Error
 }
 
@@ -707,6 +1123,29 @@ func (r TranscodeActivityHeartbeatResponse) StatusCode() int {
 	return 0
 }
 
+type ListDiscsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DiscList
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDiscsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDiscsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type CreateDiscResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -756,6 +1195,104 @@ func (r GetDiscResponse) StatusCode() int {
 	return 0
 }
 
+type GetDiscHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DiscHistory
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDiscHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDiscHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PauseDiscResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PauseDiscResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PauseDiscResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReprocessDiscResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *DiscWorkflow
+	JSON400      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReprocessDiscResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReprocessDiscResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResumeDiscResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ResumeDiscResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResumeDiscResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetInboxResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -830,6 +1367,15 @@ func (c *ClientWithResponses) TranscodeActivityHeartbeatWithResponse(ctx context
 	return ParseTranscodeActivityHeartbeatResponse(rsp)
 }
 
+// ListDiscsWithResponse request returning *ListDiscsResponse
+func (c *ClientWithResponses) ListDiscsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDiscsResponse, error) {
+	rsp, err := c.ListDiscs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDiscsResponse(rsp)
+}
+
 // CreateDiscWithBodyWithResponse request with arbitrary body returning *CreateDiscResponse
 func (c *ClientWithResponses) CreateDiscWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateDiscResponse, error) {
 	rsp, err := c.CreateDiscWithBody(ctx, contentType, body, reqEditors...)
@@ -856,17 +1402,61 @@ func (c *ClientWithResponses) GetDiscWithResponse(ctx context.Context, uuid open
 	return ParseGetDiscResponse(rsp)
 }
 
-// GetInboxWithResponse request returning *GetInboxResponse
-func (c *ClientWithResponses) GetInboxWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInboxResponse, error) {
-	rsp, err := c.GetInbox(ctx, reqEditors...)
+// GetDiscHistoryWithResponse request returning *GetDiscHistoryResponse
+func (c *ClientWithResponses) GetDiscHistoryWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetDiscHistoryResponse, error) {
+	rsp, err := c.GetDiscHistory(ctx, uuid, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetInboxResponse(rsp)
+	return ParseGetDiscHistoryResponse(rsp)
 }
 
-// ParseCompleteGetVideoInfoActivityResponse parses an HTTP response from a CompleteGetVideoInfoActivityWithResponse call
-func ParseCompleteGetVideoInfoActivityResponse(rsp *http.Response) (*CompleteGetVideoInfoActivityResponse, error) {
+// PauseDiscWithResponse request returning *PauseDiscResponse
+func (c *ClientWithResponses) PauseDiscWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*PauseDiscResponse, error) {
+	rsp, err := c.PauseDisc(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePauseDiscResponse(rsp)
+}
+
+// ReprocessDiscWithBodyWithResponse request with arbitrary body returning *ReprocessDiscResponse
+func (c *ClientWithResponses) ReprocessDiscWithBodyWithResponse(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReprocessDiscResponse, error) {
+	rsp, err := c.ReprocessDiscWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprocessDiscResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReprocessDiscWithResponse(ctx context.Context, uuid openapi_types.UUID, body ReprocessDiscJSONRequestBody, reqEditors ...RequestEditorFn) (*ReprocessDiscResponse, error) {
+	rsp, err := c.ReprocessDisc(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprocessDiscResponse(rsp)
+}
+
+// ResumeDiscWithResponse request returning *ResumeDiscResponse
+func (c *ClientWithResponses) ResumeDiscWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*ResumeDiscResponse, error) {
+	rsp, err := c.ResumeDisc(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResumeDiscResponse(rsp)
+}
+
+// GetInboxWithResponse request returning *GetInboxResponse
+func (c *ClientWithResponses) GetInboxWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInboxResponse, error) {
+	rsp, err := c.GetInbox(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInboxResponse(rsp)
+}
+
+// ParseCompleteGetVideoInfoActivityResponse parses an HTTP response from a CompleteGetVideoInfoActivityWithResponse call
+func ParseCompleteGetVideoInfoActivityResponse(rsp *http.Response) (*CompleteGetVideoInfoActivityResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
@@ -886,6 +1476,13 @@ func ParseCompleteGetVideoInfoActivityResponse(rsp *http.Response) (*CompleteGet
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -919,6 +1516,13 @@ func ParseCompleteTranscodeActivityResponse(rsp *http.Response) (*CompleteTransc
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -952,6 +1556,46 @@ func ParseTranscodeActivityHeartbeatResponse(rsp *http.Response) (*TranscodeActi
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListDiscsResponse parses an HTTP response from a ListDiscsWithResponse call
+func ParseListDiscsResponse(rsp *http.Response) (*ListDiscsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListDiscsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DiscList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1051,6 +1695,180 @@ func ParseGetDiscResponse(rsp *http.Response) (*GetDiscResponse, error) {
 	return response, nil
 }
 
+// ParseGetDiscHistoryResponse parses an HTTP response from a GetDiscHistoryWithResponse call
+func ParseGetDiscHistoryResponse(rsp *http.Response) (*GetDiscHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDiscHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DiscHistory
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePauseDiscResponse parses an HTTP response from a PauseDiscWithResponse call
+func ParsePauseDiscResponse(rsp *http.Response) (*PauseDiscResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PauseDiscResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReprocessDiscResponse parses an HTTP response from a ReprocessDiscWithResponse call
+func ParseReprocessDiscResponse(rsp *http.Response) (*ReprocessDiscResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReprocessDiscResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest DiscWorkflow
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResumeDiscResponse parses an HTTP response from a ResumeDiscWithResponse call
+func ParseResumeDiscResponse(rsp *http.Response) (*ResumeDiscResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResumeDiscResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetInboxResponse parses an HTTP response from a GetInboxWithResponse call
 func ParseGetInboxResponse(rsp *http.Response) (*GetInboxResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1095,12 +1913,27 @@ type ServerInterface interface {
 	// Heartbeat for the Transcode activity
 	// (POST /activity/transcode/heartbeat)
 	TranscodeActivityHeartbeat(w http.ResponseWriter, r *http.Request)
+	// List disc workflows
+	// (GET /disc)
+	ListDiscs(w http.ResponseWriter, r *http.Request)
 	// Create a disc workflow
 	// (POST /disc)
 	CreateDisc(w http.ResponseWriter, r *http.Request)
 	// Get disc workflow status
 	// (GET /disc/{uuid})
 	GetDisc(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Get disc workflow timeline
+	// (GET /disc/{uuid}/history)
+	GetDiscHistory(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Pause a disc workflow
+	// (POST /disc/{uuid}/pause)
+	PauseDisc(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Reprocess a disc workflow from a stage
+	// (POST /disc/{uuid}/reprocess)
+	ReprocessDisc(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Resume a disc workflow
+	// (POST /disc/{uuid}/resume)
+	ResumeDisc(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
 	// List inbox disc paths
 	// (GET /inbox)
 	GetInbox(w http.ResponseWriter, r *http.Request)
@@ -1157,6 +1990,20 @@ func (siw *ServerInterfaceWrapper) TranscodeActivityHeartbeat(w http.ResponseWri
 	handler.ServeHTTP(w, r)
 }
 
+// ListDiscs operation middleware
+func (siw *ServerInterfaceWrapper) ListDiscs(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDiscs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // CreateDisc operation middleware
 func (siw *ServerInterfaceWrapper) CreateDisc(w http.ResponseWriter, r *http.Request) {
 
@@ -1196,16 +2043,116 @@ func (siw *ServerInterfaceWrapper) GetDisc(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// GetInbox operation middleware
-func (siw *ServerInterfaceWrapper) GetInbox(w http.ResponseWriter, r *http.Request) {
+// GetDiscHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetDiscHistory(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetInbox(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDiscHistory(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PauseDisc operation middleware
+func (siw *ServerInterfaceWrapper) PauseDisc(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PauseDisc(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReprocessDisc operation middleware
+func (siw *ServerInterfaceWrapper) ReprocessDisc(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReprocessDisc(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResumeDisc operation middleware
+func (siw *ServerInterfaceWrapper) ResumeDisc(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResumeDisc(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInbox operation middleware
+func (siw *ServerInterfaceWrapper) GetInbox(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInbox(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
 	handler.ServeHTTP(w, r)
 }
@@ -1304,229 +2251,473 @@ func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
 	})
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("POST "+options.BaseURL+"/activity/get_video_info/complete", wrapper.CompleteGetVideoInfoActivity)
+	m.HandleFunc("POST "+options.BaseURL+"/activity/transcode/complete", wrapper.CompleteTranscodeActivity)
+	m.HandleFunc("POST "+options.BaseURL+"/activity/transcode/heartbeat", wrapper.TranscodeActivityHeartbeat)
+	m.HandleFunc("GET "+options.BaseURL+"/disc", wrapper.ListDiscs)
+	m.HandleFunc("POST "+options.BaseURL+"/disc", wrapper.CreateDisc)
+	m.HandleFunc("GET "+options.BaseURL+"/disc/{uuid}", wrapper.GetDisc)
+	m.HandleFunc("GET "+options.BaseURL+"/disc/{uuid}/history", wrapper.GetDiscHistory)
+	m.HandleFunc("POST "+options.BaseURL+"/disc/{uuid}/pause", wrapper.PauseDisc)
+	m.HandleFunc("POST "+options.BaseURL+"/disc/{uuid}/reprocess", wrapper.ReprocessDisc)
+	m.HandleFunc("POST "+options.BaseURL+"/disc/{uuid}/resume", wrapper.ResumeDisc)
+	m.HandleFunc("GET "+options.BaseURL+"/inbox", wrapper.GetInbox)
+
+	return m
+}
+
+type CompleteGetVideoInfoActivityRequestObject struct {
+	Body *CompleteGetVideoInfoActivityJSONRequestBody
+}
+
+type CompleteGetVideoInfoActivityResponseObject interface {
+	VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error
+}
+
+type CompleteGetVideoInfoActivity200Response struct {
+}
+
+func (response CompleteGetVideoInfoActivity200Response) VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type CompleteGetVideoInfoActivity400JSONResponse Error
+
+func (response CompleteGetVideoInfoActivity400JSONResponse) VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteGetVideoInfoActivity410JSONResponse Error
+
+func (response CompleteGetVideoInfoActivity410JSONResponse) VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteGetVideoInfoActivity500JSONResponse Error
+
+func (response CompleteGetVideoInfoActivity500JSONResponse) VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteTranscodeActivityRequestObject struct {
+	Body *CompleteTranscodeActivityJSONRequestBody
+}
+
+type CompleteTranscodeActivityResponseObject interface {
+	VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error
+}
+
+type CompleteTranscodeActivity200Response struct {
+}
+
+func (response CompleteTranscodeActivity200Response) VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type CompleteTranscodeActivity400JSONResponse Error
+
+func (response CompleteTranscodeActivity400JSONResponse) VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteTranscodeActivity410JSONResponse Error
+
+func (response CompleteTranscodeActivity410JSONResponse) VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteTranscodeActivity500JSONResponse Error
+
+func (response CompleteTranscodeActivity500JSONResponse) VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TranscodeActivityHeartbeatRequestObject struct {
+	Body *TranscodeActivityHeartbeatJSONRequestBody
+}
+
+type TranscodeActivityHeartbeatResponseObject interface {
+	VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error
+}
+
+type TranscodeActivityHeartbeat200Response struct {
+}
+
+func (response TranscodeActivityHeartbeat200Response) VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type TranscodeActivityHeartbeat400JSONResponse Error
+
+func (response TranscodeActivityHeartbeat400JSONResponse) VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TranscodeActivityHeartbeat410JSONResponse Error
+
+func (response TranscodeActivityHeartbeat410JSONResponse) VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type TranscodeActivityHeartbeat500JSONResponse Error
+
+func (response TranscodeActivityHeartbeat500JSONResponse) VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDiscsRequestObject struct {
+}
+
+type ListDiscsResponseObject interface {
+	VisitListDiscsResponse(w http.ResponseWriter) error
+}
+
+type ListDiscs200ResponseHeaders struct {
+	CacheControl string
+	Expires      string
+	Pragma       string
+}
+
+type ListDiscs200JSONResponse struct {
+	Body    DiscList
+	Headers ListDiscs200ResponseHeaders
+}
+
+func (response ListDiscs200JSONResponse) VisitListDiscsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Expires", fmt.Sprint(response.Headers.Expires))
+	w.Header().Set("Pragma", fmt.Sprint(response.Headers.Pragma))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListDiscs500JSONResponse Error
+
+func (response ListDiscs500JSONResponse) VisitListDiscsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDiscRequestObject struct {
+	Body *CreateDiscJSONRequestBody
+}
+
+type CreateDiscResponseObject interface {
+	VisitCreateDiscResponse(w http.ResponseWriter) error
+}
+
+type CreateDisc201JSONResponse DiscWorkflow
+
+func (response CreateDisc201JSONResponse) VisitCreateDiscResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDisc400JSONResponse Error
+
+func (response CreateDisc400JSONResponse) VisitCreateDiscResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDisc409JSONResponse Error
+
+func (response CreateDisc409JSONResponse) VisitCreateDiscResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
-
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
+type CreateDisc500JSONResponse Error
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+func (response CreateDisc500JSONResponse) VisitCreateDiscResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	m.HandleFunc("POST "+options.BaseURL+"/activity/get_video_info/complete", wrapper.CompleteGetVideoInfoActivity)
-	m.HandleFunc("POST "+options.BaseURL+"/activity/transcode/complete", wrapper.CompleteTranscodeActivity)
-	m.HandleFunc("POST "+options.BaseURL+"/activity/transcode/heartbeat", wrapper.TranscodeActivityHeartbeat)
-	m.HandleFunc("POST "+options.BaseURL+"/disc", wrapper.CreateDisc)
-	m.HandleFunc("GET "+options.BaseURL+"/disc/{uuid}", wrapper.GetDisc)
-	m.HandleFunc("GET "+options.BaseURL+"/inbox", wrapper.GetInbox)
+	return json.NewEncoder(w).Encode(response)
+}
 
-	return m
+type GetDiscRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
 }
 
-type CompleteGetVideoInfoActivityRequestObject struct {
-	Body *CompleteGetVideoInfoActivityJSONRequestBody
+type GetDiscResponseObject interface {
+	VisitGetDiscResponse(w http.ResponseWriter) error
 }
 
-type CompleteGetVideoInfoActivityResponseObject interface {
-	VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error
+type GetDisc200ResponseHeaders struct {
+	CacheControl string
+	Expires      string
+	Pragma       string
 }
 
-type CompleteGetVideoInfoActivity200Response struct {
+type GetDisc200JSONResponse struct {
+	Body    DiscWorkflow
+	Headers GetDisc200ResponseHeaders
 }
 
-func (response CompleteGetVideoInfoActivity200Response) VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error {
+func (response GetDisc200JSONResponse) VisitGetDiscResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("Expires", fmt.Sprint(response.Headers.Expires))
+	w.Header().Set("Pragma", fmt.Sprint(response.Headers.Pragma))
 	w.WriteHeader(200)
-	return nil
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CompleteGetVideoInfoActivity400JSONResponse Error
+type GetDisc404JSONResponse Error
 
-func (response CompleteGetVideoInfoActivity400JSONResponse) VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error {
+func (response GetDisc404JSONResponse) VisitGetDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CompleteGetVideoInfoActivity500JSONResponse Error
+type GetDisc500JSONResponse Error
 
-func (response CompleteGetVideoInfoActivity500JSONResponse) VisitCompleteGetVideoInfoActivityResponse(w http.ResponseWriter) error {
+func (response GetDisc500JSONResponse) VisitGetDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CompleteTranscodeActivityRequestObject struct {
-	Body *CompleteTranscodeActivityJSONRequestBody
+type GetDiscHistoryRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
 }
 
-type CompleteTranscodeActivityResponseObject interface {
-	VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error
+type GetDiscHistoryResponseObject interface {
+	VisitGetDiscHistoryResponse(w http.ResponseWriter) error
 }
 
-type CompleteTranscodeActivity200Response struct {
-}
+type GetDiscHistory200JSONResponse DiscHistory
 
-func (response CompleteTranscodeActivity200Response) VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error {
+func (response GetDiscHistory200JSONResponse) VisitGetDiscHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type CompleteTranscodeActivity400JSONResponse Error
+type GetDiscHistory404JSONResponse Error
 
-func (response CompleteTranscodeActivity400JSONResponse) VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error {
+func (response GetDiscHistory404JSONResponse) VisitGetDiscHistoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CompleteTranscodeActivity500JSONResponse Error
+type GetDiscHistory500JSONResponse Error
 
-func (response CompleteTranscodeActivity500JSONResponse) VisitCompleteTranscodeActivityResponse(w http.ResponseWriter) error {
+func (response GetDiscHistory500JSONResponse) VisitGetDiscHistoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type TranscodeActivityHeartbeatRequestObject struct {
-	Body *TranscodeActivityHeartbeatJSONRequestBody
+type PauseDiscRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
 }
 
-type TranscodeActivityHeartbeatResponseObject interface {
-	VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error
+type PauseDiscResponseObject interface {
+	VisitPauseDiscResponse(w http.ResponseWriter) error
 }
 
-type TranscodeActivityHeartbeat200Response struct {
+type PauseDisc202Response struct {
 }
 
-func (response TranscodeActivityHeartbeat200Response) VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
+func (response PauseDisc202Response) VisitPauseDiscResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
 	return nil
 }
 
-type TranscodeActivityHeartbeat400JSONResponse Error
+type PauseDisc404JSONResponse Error
 
-func (response TranscodeActivityHeartbeat400JSONResponse) VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error {
+func (response PauseDisc404JSONResponse) VisitPauseDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type TranscodeActivityHeartbeat500JSONResponse Error
+type PauseDisc409JSONResponse Error
 
-func (response TranscodeActivityHeartbeat500JSONResponse) VisitTranscodeActivityHeartbeatResponse(w http.ResponseWriter) error {
+func (response PauseDisc409JSONResponse) VisitPauseDiscResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseDisc500JSONResponse Error
+
+func (response PauseDisc500JSONResponse) VisitPauseDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDiscRequestObject struct {
-	Body *CreateDiscJSONRequestBody
+type ReprocessDiscRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
+	Body *ReprocessDiscJSONRequestBody
 }
 
-type CreateDiscResponseObject interface {
-	VisitCreateDiscResponse(w http.ResponseWriter) error
+type ReprocessDiscResponseObject interface {
+	VisitReprocessDiscResponse(w http.ResponseWriter) error
 }
 
-type CreateDisc201JSONResponse DiscWorkflow
+type ReprocessDisc202JSONResponse DiscWorkflow
 
-func (response CreateDisc201JSONResponse) VisitCreateDiscResponse(w http.ResponseWriter) error {
+func (response ReprocessDisc202JSONResponse) VisitReprocessDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDisc400JSONResponse Error
+type ReprocessDisc400JSONResponse Error
 
-func (response CreateDisc400JSONResponse) VisitCreateDiscResponse(w http.ResponseWriter) error {
+func (response ReprocessDisc400JSONResponse) VisitReprocessDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDisc409JSONResponse Error
+type ReprocessDisc404JSONResponse Error
 
-func (response CreateDisc409JSONResponse) VisitCreateDiscResponse(w http.ResponseWriter) error {
+func (response ReprocessDisc404JSONResponse) VisitReprocessDiscResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReprocessDisc409JSONResponse Error
+
+func (response ReprocessDisc409JSONResponse) VisitReprocessDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDisc500JSONResponse Error
+type ReprocessDisc500JSONResponse Error
 
-func (response CreateDisc500JSONResponse) VisitCreateDiscResponse(w http.ResponseWriter) error {
+func (response ReprocessDisc500JSONResponse) VisitReprocessDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDiscRequestObject struct {
+type ResumeDiscRequestObject struct {
 	Uuid openapi_types.UUID `json:"uuid"`
 }
 
-type GetDiscResponseObject interface {
-	VisitGetDiscResponse(w http.ResponseWriter) error
+type ResumeDiscResponseObject interface {
+	VisitResumeDiscResponse(w http.ResponseWriter) error
 }
 
-type GetDisc200ResponseHeaders struct {
-	CacheControl string
-	Expires      string
-	Pragma       string
+type ResumeDisc202Response struct {
 }
 
-type GetDisc200JSONResponse struct {
-	Body    DiscWorkflow
-	Headers GetDisc200ResponseHeaders
+func (response ResumeDisc202Response) VisitResumeDiscResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
 }
 
-func (response GetDisc200JSONResponse) VisitGetDiscResponse(w http.ResponseWriter) error {
+type ResumeDisc404JSONResponse Error
+
+func (response ResumeDisc404JSONResponse) VisitResumeDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
-	w.Header().Set("Expires", fmt.Sprint(response.Headers.Expires))
-	w.Header().Set("Pragma", fmt.Sprint(response.Headers.Pragma))
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
-	return json.NewEncoder(w).Encode(response.Body)
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDisc404JSONResponse Error
+type ResumeDisc409JSONResponse Error
 
-func (response GetDisc404JSONResponse) VisitGetDiscResponse(w http.ResponseWriter) error {
+func (response ResumeDisc409JSONResponse) VisitResumeDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDisc500JSONResponse Error
+type ResumeDisc500JSONResponse Error
 
-func (response GetDisc500JSONResponse) VisitGetDiscResponse(w http.ResponseWriter) error {
+func (response ResumeDisc500JSONResponse) VisitResumeDiscResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -1581,12 +2772,27 @@ type StrictServerInterface interface {
 	// Heartbeat for the Transcode activity
 	// (POST /activity/transcode/heartbeat)
 	TranscodeActivityHeartbeat(ctx context.Context, request TranscodeActivityHeartbeatRequestObject) (TranscodeActivityHeartbeatResponseObject, error)
+	// List disc workflows
+	// (GET /disc)
+	ListDiscs(ctx context.Context, request ListDiscsRequestObject) (ListDiscsResponseObject, error)
 	// Create a disc workflow
 	// (POST /disc)
 	CreateDisc(ctx context.Context, request CreateDiscRequestObject) (CreateDiscResponseObject, error)
 	// Get disc workflow status
 	// (GET /disc/{uuid})
 	GetDisc(ctx context.Context, request GetDiscRequestObject) (GetDiscResponseObject, error)
+	// Get disc workflow timeline
+	// (GET /disc/{uuid}/history)
+	GetDiscHistory(ctx context.Context, request GetDiscHistoryRequestObject) (GetDiscHistoryResponseObject, error)
+	// Pause a disc workflow
+	// (POST /disc/{uuid}/pause)
+	PauseDisc(ctx context.Context, request PauseDiscRequestObject) (PauseDiscResponseObject, error)
+	// Reprocess a disc workflow from a stage
+	// (POST /disc/{uuid}/reprocess)
+	ReprocessDisc(ctx context.Context, request ReprocessDiscRequestObject) (ReprocessDiscResponseObject, error)
+	// Resume a disc workflow
+	// (POST /disc/{uuid}/resume)
+	ResumeDisc(ctx context.Context, request ResumeDiscRequestObject) (ResumeDiscResponseObject, error)
 	// List inbox disc paths
 	// (GET /inbox)
 	GetInbox(ctx context.Context, request GetInboxRequestObject) (GetInboxResponseObject, error)
@@ -1714,6 +2920,30 @@ func (sh *strictHandler) TranscodeActivityHeartbeat(w http.ResponseWriter, r *ht
 	}
 }
 
+// ListDiscs operation middleware
+func (sh *strictHandler) ListDiscs(w http.ResponseWriter, r *http.Request) {
+	var request ListDiscsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDiscs(ctx, request.(ListDiscsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDiscs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListDiscsResponseObject); ok {
+		if err := validResponse.VisitListDiscsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // CreateDisc operation middleware
 func (sh *strictHandler) CreateDisc(w http.ResponseWriter, r *http.Request) {
 	var request CreateDiscRequestObject
@@ -1771,6 +3001,117 @@ func (sh *strictHandler) GetDisc(w http.ResponseWriter, r *http.Request, uuid op
 	}
 }
 
+// GetDiscHistory operation middleware
+func (sh *strictHandler) GetDiscHistory(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request GetDiscHistoryRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDiscHistory(ctx, request.(GetDiscHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDiscHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDiscHistoryResponseObject); ok {
+		if err := validResponse.VisitGetDiscHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PauseDisc operation middleware
+func (sh *strictHandler) PauseDisc(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request PauseDiscRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PauseDisc(ctx, request.(PauseDiscRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PauseDisc")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PauseDiscResponseObject); ok {
+		if err := validResponse.VisitPauseDiscResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReprocessDisc operation middleware
+func (sh *strictHandler) ReprocessDisc(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request ReprocessDiscRequestObject
+
+	request.Uuid = uuid
+
+	var body ReprocessDiscJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReprocessDisc(ctx, request.(ReprocessDiscRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReprocessDisc")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReprocessDiscResponseObject); ok {
+		if err := validResponse.VisitReprocessDiscResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResumeDisc operation middleware
+func (sh *strictHandler) ResumeDisc(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request ResumeDiscRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResumeDisc(ctx, request.(ResumeDiscRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResumeDisc")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResumeDiscResponseObject); ok {
+		if err := validResponse.VisitResumeDiscResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetInbox operation middleware
 func (sh *strictHandler) GetInbox(w http.ResponseWriter, r *http.Request) {
 	var request GetInboxRequestObject
@@ -1798,36 +3139,72 @@ func (sh *strictHandler) GetInbox(w http.ResponseWriter, r *http.Request) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xZX2/bOBL/KgTvHu4A2ZJTJ9v103WTbBug2Avc7N4diqCgybHNrURqScqJr/B3Pwwp",
-	"2ZZF2zm0brtAXoJEIkczv/nN33yiXBelVqCcpaNP1PI5FMz/eqmLMgcHr8H9JgXoGzXVr7iTC+mWY/ij",
-	"AuvwWGl0CcZJ8JfAGG3wFwGWG1k6qRUd0Wt8TAqwls2AyClxcyCsFkamTOYgaELhkeE36Yj+7B8Rp4kB",
-	"ZyQsgCxQCSLVVBNRAb5S4B60+UjCRxPqliXetc5INaOrhBqwVe61/KuBKR3Rv6Qbc9Pa1vQJho6DoFVC",
-	"nf4IqmvgT8zCxbAHimsBgkykYmZJwuFtu8Tr3/4rLgfZ5MzlEzn4+J9/j+c0oVNtCuboiE6WDmKWVJUU",
-	"3a/++uvNFXFz5sgDs6SyATHrmHEe4W2L1nD3Wwqdn2fwcphlPTj7cdIbDsSwx34YXPSGw4uL8/PhMMuy",
-	"bFtBr0hHQY/1H5U0IOjofQ3S/fqYnvwO3MP3dKw71OJzVjowV5VhaL59B1wrYbuovJXWET0l9QUimhtE",
-	"KmLrW1sYvL/IsmRwhj9eZtl9QqWDwstdWy10Ncm3HKOqYgLG8yE8YMawZeCHY3mj414V7/DUWjFUFt3V",
-	"MDym5AtU8rhCqwOY3xmmLPLzi0axa6TGwvhu52UTuVLZajqVXIJyxIDVleFgY8T/U8XbGotvG2MGmIMr",
-	"afleB5fMzbvm3TI3R4PQFCENcKfNknCtHJNKqln9wnL/zJeMbTPTQrkUX1v/M8sGT0f2Mkcq9EqjMQgE",
-	"8UhPtdl8EjP9NNcPp0e2PuQxiuGLyP6r0eazYqex6XAFLI3mYK2HIYboVOZwIA3612QC6MBaFEbMsgst",
-	"snad+w5VzG0EfpY5xBKhdcxVAZG1TdwzUxyixdfxba3cMe96205biPr7K1H//DNrkThWhq6iBQgJs09D",
-	"LEP98+Qp2qAYxQpoe9V/ol+UwxgJSgMLCQ+3R7PTDBQYpBKprzSq1xljY0g7E6eK2bQAIVlaX7SpP/uh",
-	"/jOu2Q6J1pbF+HPdxP8OabTYQeLml7vr8S+v3n64Ho//OY7BUWeL9rVXKvS7RHNeGQPHOe8/vZEWU/oN",
-	"MOMmwNzTe4TS6JkBG2FV3W8gr0owHJTDlPe3rDfIsr9vu+OHKJEK9iiLqqCjAXY8hVThryxG+Ofm4FBz",
-	"kGycFHP6jZroxzHYUisL8R7hQFLzlcOfwVyBBkqU10pn8Zag8/As9vAF3c5+3b6wlet2zA+qd23GczhA",
-	"hoBUjnFPbCiYzFF6VZbauH/UBvS5LmhCQw6jr25vyLtwwOfWFij40oJZSA4+BxVMsRkW3FZ99Q2udN7t",
-	"WGZIU2fIu3CXJnQBxgaZg37Wz/BTugTFSklH9EU/67+oWxMPS9oMdekM3IeQydBAX7Sx5/eO1CF+o1EK",
-	"dv+kSB4kptw5kHVf1hALieJLx43YkhWb6mhwDVj3kxbLBnlQXiVWlrnkXlD6u9Vqs4L4AkN78PeGF85U",
-	"EJYCnvEewLMs60LTyCENioLYimPjNK3y3JfWYbj3RUwJFcMru5vHBDGNLQk9/xrfvFEOjGK5pzOYereC",
-	"52xVFMwst9y9nzn+woac69T3f/NyM0AeIyVhSjQOa5eevXTtFLsTc3VvcX0m6lcgapdKe1k6b9qh/TR9",
-	"B0pYwsj66Lr3PAllO9RZd2wn4uzxjvCZtKch7ZsnMCow1y8E9idSP2wjRxU8tBuRQEhGeGzrgqTcbH/8",
-	"FqSTQNcbplNlzM4K60lsG3wxBVo7nogjr1pw1nuNb85j0vN8sSVwOZU4HePMLDRYorQj8CgD14fZj6fX",
-	"61KraS55o9RMLkAFhklLWG6AiSWODpWF76toeGcStrN2XEdc+glHsBXqMINI3I3BVUaF9sVP6MrhFOgA",
-	"p6YdqWSy9JB0Iuw1uDq8SmZYAQ6MpaP30ZmzXt7sbkklHqjDt55h6tmxHUbJFqjHpsz7eIL/BiE31ZVC",
-	"DefAhMfmE71kfA69S62c0Xlk2SUtm+RAOONzHM1CdpWWgBKllgor6UbTzYCudA+vQEKU7lmnDSSkqKzr",
-	"GViwXAoWWxKsEnr9WEoTW8yGF0QWfhHlIF/u+XIWlXtr2CycbIt9c3d3Swb9jEwY//jATGgtmJMTmfv/",
-	"smrjbQe/vUeIDtsb+fjK547h6eO07WvMXcHf31OeeA1uJ5jrrXJCHZthtFJfoO995gjLkWM5g5H86Hql",
-	"kyhu6hcni8z2oigCV7MT8ipuqf4cn18/Pr+b+PCkiDBiNzjwkpcSK3BvNWc5EbCAXJeFL6X+LE1oZXI6",
-	"onPnylGa5nhurq0bvcxeZnSVdP57YLSouB+zIhLsKE1ZKfvbm7/V/ep/AQAA//9pTC9mHiMAAA==",
+	"H4sIAAAAAAAC/+xce2/cRpL/Kg3eAbkDqBlKHimO/M85thML8CWC7N2924wh9JDFYUdkN7e7OeOJoe++",
+	"qOrma8h5GLEcBfE/jobsR1V1/erVxXwMYlWUSoK0Jrj8GJg4g4LTny9UUeZg4UewfxcJqCuZquexFSth",
+	"NzfwrwqMxWGlViVoK4AmgdZK4x8JmFiL0golg8vgFT5mBRjDl8BEymwGjPvFWMpFDkkQBvCB457BZfAD",
+	"PWJWMQ1WC1gBWyERTMhUsaQCfCXBrpW+Y27TMLCbEucaq4VcBvdhoMFUOVH5nxrS4DL4j2nL7tTzOj2C",
+	"0Ru30H0YWHUHcsjg99zAxewEZKwSSNhCSK43zA3u8pX8+Pffkhen0eLM5gtxevf//3eTBWGQKl1wG1wG",
+	"i42FMU6qSiTDXf/2t6uXzGbcsjU3rDJOYsZybUnCXY4acU8Y+9/KWFZwG2c07Fe1oP8SubSUMKaChKVK",
+	"T3rkn59H8HQWRSdw9t3iZHaazE74t6cXJ7PZxcX5+WwWRVHUZYfIHrBDJ/OvSmhIgstfvEjfN8PU4leI",
+	"SdjHn8xAEeOMlxb0y0pzFJZ5C7GSiRnK8I0wlqmU+QksqWcwIZnxszoy+OUiisLTM/znaRS9DwNhoaB1",
+	"G64TVS3yzjHKqliADu6bB1xrvqHfyvK8pnEnie9wVEMYEmuzFg9jRD5BIg8TdL9H5u80lwa1+bNi3tar",
+	"joH+3dbLGudCmipNRSxAWqbBqErHYMZg8qdCZyOLPxMiNXALL4WJd6pDyW02FMY1txmyj1wlQkNsld6w",
+	"WEnLhRRy6V+YmJ6RO+qyOS2kneJrQ/9G0enx5/AiR8U5KbVCyCSMziVVut0SvUiaq/XDS9YPIhmNyRcl",
+	"+1oYFM4I0KTV/s8tAyEKyIUE5kegVUDmlE6A2NywBSy5nAQdc7XPJXbIeCWt3ozZrlrYX0ZiNe8HhOao",
+	"HUiOWwtFaUck141D/CiHWcIpJCznxvYgd9ZQIKSFpTPsca4MJM9HdvhHBu4wkIMNS4UUJoNk0hVEwi2c",
+	"WFGMGpfkoHsQBbBUq4Lh8SVVjoQg1mqiQiZknFcJwozLjQ+pTI+r07PJeXiMC9th7TFgqzTU9j6sDb7n",
+	"muz5ZIy9VOQwXA7PBd8wpTvmohc1ImghYUrSXlxuJn2LIbmZFpAIPs3FQnO9mR6jn1MrbA63Noomxd1q",
+	"jOA7IZOxY+a2w7B7ufBCllWBqtwxMzUXQRgYsZQ8D8KgKlEPgvcje0pejAip9swMx4fMLcRwLIrNLUc/",
+	"J4z9oHRj5mozERLB7dMVSHs5l17zQxb7UCAJ/QGGDJU0+bmyIYu5jME9A10IyREsSpP5FrKC5Ln5CdaT",
+	"ed/DdiO5MeF2NPgglMikPSNL7sVJPK3rUanQxjagRifarH48+ozlyx362QiOxjir4d10V1EnjD1fGJCW",
+	"KCUf1hiXBaRKg1vAsDVoYBpiNNtJX5sTwZdSGStis4NKbQ8ILecdaShETIslP/+TxGKrEVvUSDhkupLo",
+	"2PeqESmMV6Stg2RcJl6HzbO51BCDWPlRTtPNM+LLoL5z4/Q+HVHzbR1s6DnoewjqHn0Nz7s8ECYSQ89D",
+	"5z2U08tu2IFKK2ymKrIgQpPlM5/iq//hVxr66S2OHDm7WLjOuBlR9tdqzVKuGXfRUsYNWyo7YSymaDBh",
+	"wjAlc3IslZao1xv/DlcN6Sgbqbuhi81c5sJYHECgFXHGEgWGSWWZBp4w4PhImPgbAoyFZ2wJNIFpKJW2",
+	"ptXrEilnvN1lLhM3kKNGMiGNBZ5MGCs5ReLC+EUctfXCC7BrAOlG0ROkXYOpCs+LkHNZ5jyGOg9zWzex",
+	"pDBMSK9y3u57MQVh4DERhEHj1G4LtaJ3dOi3KBP6uVT2Fh/d9qHvyA/Cnhb7XOp9V8u3lhsguKc2vyun",
+	"a/C2t45TahWDMSSkXXHAnvScXrMFYAzjl3InNwjiQxf2+rfozZmLXugoLZpDVL6OX+Se8s4SNgPUJw3e",
+	"k+RgGk3IrWHCsoyjUuc5xB1LbjOYy9TFQk4JPhnDP4gcxuJt0rNX0oKuDT1PEoFi4vl17/COjCvH3QTJ",
+	"Etw2DoJOwYljUwMqZHewcSKm19uSTAgr7sdcCodUpGQr5JDo6Rj7SVkfo7o1G8uwZbs/DnBzGZxFZxcn",
+	"0elJdPbu9Pwyml1GF//sQG1swPk/g7HSR+vUDp2VM5RfPAk64IF6GvSwFbHJ7pLY5Px3FsUOJjwvRyth",
+	"lC7soBDrYUfmN7jMjnB7YVReWWCYvQ/3/kLpx1iF463lC2Q+AWlFKkA35Q0SCgYXQjJhjcfllreNMy6X",
+	"0Pg+7So7fQkGT9KL+Ix/lzw9XUQzOP82ODpNweqbz0r2y+wg76WGlYD19cEK0xIkaApN/JR6Ty+WI07N",
+	"TzRTGnvrf06KcjZ+05BzK1awh7ShstaT2roYxTqNiftE6RjxG3y/sWNe9K34DcbRgiXN/kmfnc6+nT19",
+	"cjF72i0hC2kvZsGw9LFlqEQdyfiY2f+nJ54x8/Wqjje2bJZKoG9er3569+rmp+dvbl/d3Px8MyYGH530",
+	"pz2X7paIqTiutD4i9qet29XGiH4NXNsFcHt8rbzUaqnBjByRr7ujWStBxyApqfyv6OQ0iv67ez7fjtqx",
+	"gn8QBYabp1j5L4R0v6Ixe/u1SP75iuRhe6RjKnIlF+rDDZhSSQPjtfI9HphiMRpTV3QFrtfzveOl8cHD",
+	"s7GHT4Kuqx6c2N5M0pE+xvMN+AB97zXBjsLKW0u1FMV0vUoblWKNc8IoOLx1ITn+abq20z3mSy5kG+4v",
+	"wZq5dKl1J5t61v3B7gBKn1BSztQL+mt/YnyC3rmL5jKZy9pZPMP6Ls9v22uu5i+3doGEuaSEiJww1i8F",
+	"sESRT27mzeXWvA3YkBk12EkYxvM13ximISUgod9nnM2i78jxSbXu56WtICkh7aaZW4v3U8u9xagtNXHH",
+	"PFQTHIfyc1ZeWh6TjkDBRY6rVWWptP0fv+kkVkXtTS6D59dX7K0bMExk8KUBvRKxqwYVXPIlZo29JJEI",
+	"R3HWtZg6dmZv3dwgDFagjVvzdBJNItxKlSB5KTAcmkSTJ/4mh/R5WhfTpkuwty5oQAandaJOEFDG7jT9",
+	"XkVGL+3dWeLr5hqrtj8IKgqHr5LOWmNX5oE7GjD2e5VsasmDdMlkWeYipoWmvxol226Qz9A/4c671Qur",
+	"K6AHzjCSAM+iaE+Juy0fmSpGs5BWeb7BXJ+kEvM8X/D4jtwAZ0nlmKGgR0lgPNfAk01bOsDTnEXRZxOC",
+	"C2CIzW23mtQRdUMtOb0m/D7OoxHBp1+A4N6VGKb8tew6dVyq3zJVOZakYrmSS9AMPghjDZJ6/iVkeyUt",
+	"aLzzQMDj9n5gGJiqKLjedACxG1s0oYVvY/Q+Gblt/8Ih2HYrov2IbyegBzHmA6N5Z0z7FcpfofwIoDwE",
+	"204cZ3WethvIb0EmqG3N0KZU8CCgHoCrSSUfCNWHU9UHgvVXcP5FwPn6COQ4hNIN0OXHYAkjQLyhK0SD",
+	"zQjYRtGN2V2Z4B0UpdJIixV5zu4kJk18QTJKVZ6rdX0vpAywNf2rKhurApgwc0m3j3dQ2jqldjx942qj",
+	"zFil6TbDZX8amOzcTTxjlYHmstAzWrisqo/wN/UNRjAOos9yYM2188iZbaWVqaroLjsDnoB2Dd54k3Py",
+	"QkmrVT56RU1V5ZjHGeZPjlthGMikVELaIOyQ2WaHUp3gFAiZVCckzpAVlbEnGlY8F9TjMpY1vvpQCj1W",
+	"vHQvmCioMGsh3+zYORpd91rzZcGHy75+9+6anU4ihsHGmmtnu7kVC5FTVzr1JsQYkXgR7ed3ZPP7xwRQ",
+	"KioNk2C+NHVjQPD+PtzhH13fJ3pICestWPoyQzzWZokuse3fwmQZ22HoDxZz6XsBYI5XdWD8ZbySvuDD",
+	"LeNkA0NWSStyZwGaJgS6AWQC4ekr6P6qZQyQbefqQwXOg9bYo1zq6We1Bm0jyAGL0PRu/NHOmp04K1xC",
+	"LFIBidONxmmTp3N0fffwdL1QMs1FXBO1FCuQTpFF65+FRC9AHplLZTPQTedHOwS1uW5rJrWfy6u6D8ta",
+	"QNPiu0bqbgosbpk2bFFpq+2oz48qECfdqfuB1q3Oee8+/YgV9PuDTp7SvUprkNZ1+CDXW6uiQUCBTBj7",
+	"WcbQ+v82Gsq4W6qeEs6lsMYVSP261O7DE9cmu8PrUx8p2h5h0OSnYllpSMZsyY8uAKDyn+YFWPKov4ze",
+	"bfhbt+0+c4EDUDHasqa/degbjK7POXQ/8f6BQ43jjcvXaOMPijZm0ezhTUT/rNFKu/N+TCbqR9gKdphv",
+	"nhmEPFtGa5q1n1/sNV4uMqHPLsasViVy68yNsKa1WtRkzfwel22zcj1xLjFkor4rldLUTkus5jJkmVqT",
+	"4XPfdVil7tyzAlv7fX+vcS8lAEZiuOI6o1a5jetOw9yS0yJNLcP31HbbbpmwrO67JfObbxhfcZETUteZ",
+	"yGErHZvLbVv8jamZ3WNI6y9e/mL2tGb7IMRqRQu+InwfwhsxHcQ4ddHuqQZaVZqhmjk4U29C/SlNoTR0",
+	"EIrJTfPDxW9ctyVr35M4l/gwh5S+0nFfBE0Yu+aVoXXrHuUm0ZGKQZpCbMcwdF23Kj9++JyNtUZVBupc",
+	"gMzMWI+3F4jrRFUYBvbaSKl6hlpI6eDkMcDkj0hX+sraLSnWX509KgC7o+cDXTyA3aYxZB9+ubZ1sUJX",
+	"0jnoWghbcqqk/0jSfUnicw2sztazzZ3w5sB9qDOXbbe3zxGVBPwkSuuNT/qEbnrFFQqgTTwoH7F0ewAr",
+	"oSpDe1AnNHnj0IUApVZJFfuMsFkqbT5OXAK56pxb0Li9YTzVYDJPO/GIgTCp5QKaz3Hc6sKa/v40NOMr",
+	"YEtlmc20qpaZr8K63cZ4HrNHvfafR2mTPn/tZ7Tl6ajyz9kXy9AaGlFD/Qdff1DBp5JYtpdOsf7S1loY",
+	"f49Rw5MpPQRnIpL6syh/g9VI7tFY80a9BqkQmT7uST7CvOO3Vrtt+xsgy94Nkbopnra9bwh9jx3+X0IK",
+	"F1vRDP+tJgnVr+TirLncHWjdNF+B/SkjLUd+G2p9jZL+FFGSP7ajwiTXHXy4cJEf7C8epOlX/sWDJcT9",
+	"TukRYdVN0URih/SvZca/+qXmiEZsgwMn0Spj5vqNinnOElhBrsqCLiNobBAGlc6DyyCztrycTnMclylj",
+	"L59GT7EfeJDMUsyOP8ZWMJfTKS/FpNvTfP/+/t8DAFWxHKSDTQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
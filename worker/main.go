@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
 
 	"github.com/krelinga/video-info/virest"
 	"github.com/krelinga/video-transcoder/vtrest"
@@ -10,12 +12,14 @@ import (
 	"github.com/krelinga/video-workflows/internal/vwactivity"
 	"github.com/krelinga/video-workflows/internal/workflows/vwdisc"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/worker"
 )
 
 func main() {
 	if err := mainImpl(); err != nil {
-		log.Fatal(err)
+		slog.Error("Exiting with error", internal.LogKeyError, err)
+		os.Exit(1)
 	}
 }
 
@@ -23,49 +27,112 @@ func mainImpl() error {
 	// Load configuration from environment
 	config := internal.NewWorkerConfigFromEnv()
 
+	// Set up logging
+	logger := internal.NewLogger(config.Logging, nil)
+	slog.SetDefault(logger)
+
+	// Set up tracing
+	tracerProvider, err := internal.NewTracerProvider(context.Background(), config.Tracing, "video-workflows-worker")
+	if err != nil {
+		return fmt.Errorf("failed to create tracer provider: %w", err)
+	}
+	defer tracerProvider.Shutdown(context.Background())
+	tracingInterceptor, err := internal.NewTracingInterceptor()
+	if err != nil {
+		return fmt.Errorf("failed to create tracing interceptor: %w", err)
+	}
+
 	// Create Temporal client
 	temporalClient, err := client.Dial(client.Options{
-		HostPort: fmt.Sprintf("%s:%d", config.Temporal.Host, config.Temporal.Port),
+		HostPort:     fmt.Sprintf("%s:%d", config.Temporal.Host, config.Temporal.Port),
+		Interceptors: []interceptor.ClientInterceptor{tracingInterceptor},
+		Logger:       internal.NewTemporalLogger(logger),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create Temporal client: %w", err)
 	}
 	defer temporalClient.Close()
 
-	// Create worker
-	w := worker.New(temporalClient, internal.TaskQueue, worker.Options{})
+	// Create one worker per task queue this process serves.
+	var workers []worker.Worker
+	var taskQueues []string
+	if config.HasRole(internal.WorkerRoleWorkflow) {
+		w := worker.New(temporalClient, internal.TaskQueue, workerOptions(config.Concurrency, 0))
+		w.RegisterWorkflow(vwdisc.Workflow)
+		w.RegisterWorkflow(vwdisc.ClaimSource)
+		workers = append(workers, w)
+		taskQueues = append(taskQueues, internal.TaskQueue)
+	}
 
-	// Register workflows
-	w.RegisterWorkflow(vwdisc.Workflow)
+	if config.HasRole(internal.WorkerRoleFilesystem) {
+		w := worker.New(temporalClient, config.TaskQueues.Filesystem, workerOptions(config.Concurrency, config.Concurrency.FilesystemActivitiesPerSecond))
+		w.RegisterActivity(vwactivity.RenameFile)
+		w.RegisterActivity(vwactivity.ListVideoFiles)
+		w.RegisterActivity(vwactivity.MkDir)
+		workers = append(workers, w)
+		taskQueues = append(taskQueues, config.TaskQueues.Filesystem)
+	}
 
-	// Register activities
-	w.RegisterActivity(vwactivity.RenameFile)
-	w.RegisterActivity(vwactivity.ListVideoFiles)
-	w.RegisterActivity(vwactivity.MkDir)
+	if config.HasRole(internal.WorkerRoleRemote) {
+		w := worker.New(temporalClient, config.TaskQueues.Remote, workerOptions(config.Concurrency, config.Concurrency.RemoteActivitiesPerSecond))
 
-	viClient, err := virest.NewClientWithResponses(fmt.Sprintf("%s:%d", config.VideoInfoHost, config.VideoInfoPort))
-	if err != nil {
-		return fmt.Errorf("failed to create VideoInfo client: %w", err)
-	}
-	videoInfoDeps := &vwactivity.VideoInfoDeps{
-		Client: viClient,
-	}
-	w.RegisterActivity(videoInfoDeps.GetVideoInfo)
+		viHTTPClient, err := internal.NewDependencyHTTPClient(config.VideoInfo)
+		if err != nil {
+			return fmt.Errorf("failed to create VideoInfo HTTP client: %w", err)
+		}
+		viClient, err := virest.NewClientWithResponses(config.VideoInfo.BaseURL, virest.WithHTTPClient(viHTTPClient))
+		if err != nil {
+			return fmt.Errorf("failed to create VideoInfo client: %w", err)
+		}
+		videoInfoDeps := &vwactivity.VideoInfoDeps{
+			Client:       viClient,
+			CallbackMode: config.CallbackMode,
+			PollInterval: config.PollInterval,
+		}
+		w.RegisterActivity(videoInfoDeps.GetVideoInfo)
 
-	tClient, err := vtrest.NewClientWithResponses(fmt.Sprintf("%s:%d", config.TranscodeHost, config.TranscodePort))
-	if err != nil {
-		return fmt.Errorf("failed to create VTRest client: %w", err)
+		tHTTPClient, err := internal.NewDependencyHTTPClient(config.Transcode)
+		if err != nil {
+			return fmt.Errorf("failed to create VTRest HTTP client: %w", err)
+		}
+		tClient, err := vtrest.NewClientWithResponses(config.Transcode.BaseURL, vtrest.WithHTTPClient(tHTTPClient))
+		if err != nil {
+			return fmt.Errorf("failed to create VTRest client: %w", err)
+		}
+		transcodeDeps := &vwactivity.TranscodeDeps{
+			Client:       tClient,
+			CallbackMode: config.CallbackMode,
+			PollInterval: config.PollInterval,
+		}
+		w.RegisterActivity(transcodeDeps.Transcode)
+
+		workers = append(workers, w)
+		taskQueues = append(taskQueues, config.TaskQueues.Remote)
 	}
-	transcodeDeps := &vwactivity.TranscodeDeps{
-		Client: tClient,
+
+	if len(workers) == 0 {
+		return fmt.Errorf("no worker roles configured in %s", internal.EnvWorkerRoles)
 	}
-	w.RegisterActivity(transcodeDeps.Transcode)
 
-	// Start worker
-	log.Printf("Starting worker on task queue: %s", internal.TaskQueue)
-	if err := w.Run(worker.InterruptCh()); err != nil {
-		return fmt.Errorf("failed to start worker: %w", err)
+	// Start workers
+	slog.Info("Starting worker", "task_queues", taskQueues)
+	for _, w := range workers {
+		if err := w.Start(); err != nil {
+			return fmt.Errorf("failed to start worker: %w", err)
+		}
+		defer w.Stop()
 	}
+	<-worker.InterruptCh()
 
 	return nil
 }
+
+// workerOptions builds the options for a worker polling a single task queue, dispatching at most
+// activitiesPerSecond activities across the whole queue (0 for no limit).
+func workerOptions(config *internal.ConcurrencyConfig, activitiesPerSecond float64) worker.Options {
+	return worker.Options{
+		MaxConcurrentActivityExecutionSize:     config.MaxConcurrentActivities,
+		MaxConcurrentWorkflowTaskExecutionSize: config.MaxConcurrentWorkflowTasks,
+		TaskQueueActivitiesPerSecond:           activitiesPerSecond,
+	}
+}